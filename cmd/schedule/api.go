@@ -0,0 +1,337 @@
+// cmd/schedule/api.go
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"SummerCamp25/planner"
+)
+
+const (
+	apiCommandLiteral              = "api"
+	defaultAPIAddressLiteral       = "127.0.0.1:8081"
+	apiListeningFormatLiteral      = "planner API on http://%s\n"
+	queryTitleParameterLiteral     = "q"
+	queryDayParameterLiteral       = "day"
+	queryFromParameterLiteral      = "from"
+	queryToParameterLiteral        = "to"
+	queryAgeParameterLiteral       = "age"
+	queryChildParameterLiteral     = "child"
+	queryAvailableParameterLiteral = "available"
+	maximumRequestBytes            = 16 << 20
+)
+
+// planRequest is the body of POST /plan. Sessions holds scraper JSON and
+// defaults to the -sessions file; Want defaults to the -want file.
+type planRequest struct {
+	Sessions json.RawMessage     `json:"sessions,omitempty"`
+	Want     *structuredWantFile `json:"want,omitempty"`
+	Options  planRequestOptions  `json:"options"`
+}
+
+// planRequestOptions overrides the command-line planner flags for one request.
+type planRequestOptions struct {
+	BufferMinutes         *int           `json:"bufferMinutes,omitempty"`
+	TogetherBonus         *int           `json:"togetherBonus,omitempty"`
+	DiversityBonus        *int           `json:"diversityBonus,omitempty"`
+	BlackoutToleranceDays *int           `json:"blackoutToleranceDays,omitempty"`
+	Drivers               string         `json:"drivers,omitempty"`
+	TravelMinutes         map[string]int `json:"travelMinutes,omitempty"`
+	Backups               *int           `json:"backups,omitempty"`
+	Overbook              *bool          `json:"overbook,omitempty"`
+	Fairness              *string        `json:"fairness,omitempty"`
+	MaxScoreGap           *int           `json:"maxScoreGap,omitempty"`
+	// SuccessProbabilities plans for expected score; missing states keep their default chance.
+	SuccessProbabilities map[string]float64 `json:"successProbabilities,omitempty"`
+}
+
+type planAPIResponse struct {
+	Schedule     exportJSON `json:"schedule"`
+	Score        int        `json:"score"`
+	Expected     float64    `json:"expectedScore"`
+	Explanations []string   `json:"explanations,omitempty"`
+}
+
+type apiErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// apiServer answers planning requests; the files named by flags are the defaults.
+type apiServer struct {
+	values     plannerFlags
+	scale      planner.PriorityScale
+	aliases    map[string]string
+	categories planner.CategoryTable
+	sessions   []planner.Session
+	want       *planner.Preferences
+}
+
+// runAPI exposes the planner as a JSON HTTP service.
+func runAPI(arguments []string) {
+	flags := flag.NewFlagSet(apiCommandLiteral, flag.ExitOnError)
+	values := registerPlannerFlags(flags)
+	addressFlag := flags.String(flagAddressParameterNameLiteral, defaultAPIAddressLiteral, flagAddressParameterUsageLiteral)
+	_ = flags.Parse(arguments)
+
+	server := &apiServer{values: values}
+	scale, scaleError := values.loadScale()
+	if scaleError != nil {
+		fmt.Println("FATAL:", scaleError)
+		return
+	}
+	server.scale = scale
+	aliases, aliasError := values.loadAliases()
+	if aliasError != nil {
+		fmt.Println("FATAL:", aliasError)
+		return
+	}
+	server.aliases = aliases
+	categories, categoriesError := values.loadCategories()
+	if categoriesError != nil {
+		fmt.Println("FATAL:", categoriesError)
+		return
+	}
+	server.categories = categories
+	if *values.sessionsPath != emptyLiteral {
+		sessions, sessionsError := loadSessions(*values.sessionsPath)
+		if sessionsError != nil {
+			fmt.Println("FATAL:", sessionsError)
+			return
+		}
+		categories.Tag(sessions)
+		server.sessions = sessions
+	}
+	if *values.wantPath != emptyLiteral {
+		want, wantError := loadWantFile(*values.wantPath, scale)
+		if wantError != nil {
+			fmt.Println("FATAL:", wantError)
+			return
+		}
+		server.want = &want
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /plan", server.handlePlan)
+	mux.HandleFunc("GET /sessions", server.handleSessions)
+
+	fmt.Printf(apiListeningFormatLiteral, *addressFlag)
+	log.Fatal(http.ListenAndServe(*addressFlag, mux))
+}
+
+// handlePlan plans the posted (or default) sessions for the posted (or default) preferences.
+func (server *apiServer) handlePlan(writer http.ResponseWriter, request *http.Request) {
+	var body planRequest
+	if decodeError := json.NewDecoder(http.MaxBytesReader(writer, request.Body, maximumRequestBytes)).Decode(&body); decodeError != nil {
+		writeAPIError(writer, http.StatusBadRequest, decodeError)
+		return
+	}
+
+	var want planner.Preferences
+	switch {
+	case body.Want != nil:
+		converted, wantError := body.Want.toPreferences(server.scale)
+		if wantError != nil {
+			writeAPIError(writer, http.StatusUnprocessableEntity, wantError)
+			return
+		}
+		want = converted
+	case server.want != nil:
+		want = *server.want
+	default:
+		writeAPIError(writer, http.StatusBadRequest, fmt.Errorf("no want in request and no -want file"))
+		return
+	}
+
+	sessions := append([]planner.Session(nil), server.sessions...)
+	if len(body.Sessions) > 0 {
+		posted, sessionsError := planner.ParseSessions(body.Sessions)
+		if sessionsError != nil {
+			writeAPIError(writer, http.StatusBadRequest, fmt.Errorf("sessions: %w", sessionsError))
+			return
+		}
+		server.categories.Tag(posted)
+		sessions = posted
+	}
+	matches, _ := matchWantTitles(want.Titles(), distinctSessionTitles(sessions), server.aliases)
+	applyTitleMatches(sessions, matches)
+
+	options, preferences, optionsError := server.values.buildOptions(want)
+	if optionsError != nil {
+		writeAPIError(writer, http.StatusUnprocessableEntity, optionsError)
+		return
+	}
+	if overrideError := body.Options.applyTo(&options); overrideError != nil {
+		writeAPIError(writer, http.StatusBadRequest, overrideError)
+		return
+	}
+
+	result, planError := planner.New(options).Plan(request.Context(), sessions, preferences)
+	if planError != nil {
+		writeAPIError(writer, http.StatusUnprocessableEntity, planError)
+		return
+	}
+	response := planAPIResponse{
+		Schedule:     buildExport(sessions, result, preferences),
+		Score:        result.Score,
+		Expected:     result.ExpectedScore,
+		Explanations: result.Rejections,
+	}
+	if requirementError := result.RequirementsError(); requirementError != nil {
+		response.Explanations = append(response.Explanations, requirementError.Error())
+	}
+	writeJSONResponse(writer, http.StatusOK, response)
+}
+
+// applyTo sets the given overrides on options, rejecting values out of the
+// range the matching command-line flag accepts.
+func (overrides planRequestOptions) applyTo(options *planner.Options) error {
+	if overrides.BufferMinutes != nil {
+		options.BufferMinutes = *overrides.BufferMinutes
+	}
+	if overrides.TogetherBonus != nil {
+		options.TogethernessBonus = *overrides.TogetherBonus
+	}
+	if overrides.DiversityBonus != nil {
+		options.DiversityBonus = *overrides.DiversityBonus
+	}
+	if overrides.BlackoutToleranceDays != nil {
+		options.BlackoutToleranceDays = *overrides.BlackoutToleranceDays
+	}
+	if overrides.Backups != nil {
+		options.Backups = *overrides.Backups
+	}
+	if overrides.Overbook != nil {
+		options.OverbookSpots = *overrides.Overbook
+	}
+	if overrides.Fairness != nil {
+		fairness, fairnessError := parseFairness(*overrides.Fairness)
+		if fairnessError != nil {
+			return fairnessError
+		}
+		options.Fairness = fairness
+	}
+	if overrides.MaxScoreGap != nil {
+		options.MaxScoreGap = *overrides.MaxScoreGap
+	}
+	if overrides.Drivers != emptyLiteral {
+		driverCounts, driversError := parseDriverCounts(overrides.Drivers)
+		if driversError != nil {
+			return driversError
+		}
+		options.DriversByWeekday = driverCounts
+	}
+	if overrides.TravelMinutes != nil {
+		for location, minutes := range overrides.TravelMinutes {
+			if boundsError := checkOptionBounds([]optionBound{{"travelMinutes[" + strconv.Quote(location) + "]", minutes, 0, minutesPerDay}}); boundsError != nil {
+				return boundsError
+			}
+		}
+		options.TravelMinutesByLocation = overrides.TravelMinutes
+	}
+	if overrides.SuccessProbabilities != nil {
+		if options.SuccessProbabilities == nil {
+			options.SuccessProbabilities = planner.DefaultSuccessProbabilities()
+		}
+		for stateText, probability := range overrides.SuccessProbabilities {
+			state := planner.AvailabilityState(strings.ToLower(stateText))
+			if _, known := options.SuccessProbabilities[state]; !known {
+				return fmt.Errorf("successProbabilities: unknown state %q", stateText)
+			}
+			if probability < 0 || probability > 1 {
+				return fmt.Errorf("successProbabilities: %s probability %v is not between 0 and 1", stateText, probability)
+			}
+			options.SuccessProbabilities[state] = probability
+		}
+	}
+	return checkOptionBounds([]optionBound{
+		{"bufferMinutes", options.BufferMinutes, 0, minutesPerDay},
+		{"blackoutToleranceDays", options.BlackoutToleranceDays, 0, math.MaxInt},
+		{"togetherBonus", options.TogethernessBonus, 0, math.MaxInt},
+		{"diversityBonus", options.DiversityBonus, 0, math.MaxInt},
+		{"backups", options.Backups, 0, math.MaxInt},
+		{"maxScoreGap", options.MaxScoreGap, 0, math.MaxInt},
+	})
+}
+
+// handleSessions lists the loaded sessions matching every given filter:
+// q (title substring), day (weekday), from/to (dates the session overlaps),
+// age, child (right age for a -want child) and available=true.
+func (server *apiServer) handleSessions(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	titleFilter := strings.ToLower(query.Get(queryTitleParameterLiteral))
+	dayFilter := query.Get(queryDayParameterLiteral)
+
+	var fromDate, toDate time.Time
+	for _, bound := range []struct {
+		name   string
+		target *time.Time
+	}{{queryFromParameterLiteral, &fromDate}, {queryToParameterLiteral, &toDate}} {
+		if text := query.Get(bound.name); text != emptyLiteral {
+			parsed, parseError := time.Parse(dateLayoutISOLiteral, text)
+			if parseError != nil {
+				writeAPIError(writer, http.StatusBadRequest, fmt.Errorf("%s: %w", bound.name, parseError))
+				return
+			}
+			*bound.target = parsed
+		}
+	}
+	ageFilter := -1
+	if text := query.Get(queryAgeParameterLiteral); text != emptyLiteral {
+		parsed, parseError := strconv.Atoi(text)
+		if parseError != nil {
+			writeAPIError(writer, http.StatusBadRequest, fmt.Errorf("%s: %w", queryAgeParameterLiteral, parseError))
+			return
+		}
+		ageFilter = parsed
+	}
+	childFilter := query.Get(queryChildParameterLiteral)
+	var filterChild planner.Child
+	if childFilter != emptyLiteral {
+		known := false
+		if server.want != nil {
+			filterChild, known = server.want.ChildNamed(childFilter)
+		}
+		if !known {
+			writeAPIError(writer, http.StatusBadRequest, fmt.Errorf("unknown child %q", childFilter))
+			return
+		}
+	}
+	availableOnly := query.Get(queryAvailableParameterLiteral) == "true"
+
+	matching := []simpleSessionJSON{}
+	for _, session := range server.sessions {
+		switch {
+		case titleFilter != emptyLiteral && !strings.Contains(strings.ToLower(session.ActivityName), titleFilter):
+		case dayFilter != emptyLiteral && !sessionMeetsOn(session, dayFilter):
+		case !fromDate.IsZero() && planner.CalendarDate(session.EndDate()).Before(fromDate):
+		case !toDate.IsZero() && planner.CalendarDate(session.StartDate()).After(toDate):
+		case ageFilter >= 0 && !session.AdmitsAge(ageFilter):
+		case childFilter != emptyLiteral && !session.AdmitsAge(filterChild.AgeOn(session.StartDate())):
+		case availableOnly && !session.IsOpen():
+		default:
+			matching = append(matching, exportSession(planner.Preferences{}, session, nil))
+		}
+	}
+	writeJSONResponse(writer, http.StatusOK, matching)
+}
+
+func sessionMeetsOn(session planner.Session, day string) bool {
+	for _, sessionDay := range session.DaysOfWeek {
+		if strings.EqualFold(sessionDay, day) {
+			return true
+		}
+	}
+	return false
+}
+
+func writeAPIError(writer http.ResponseWriter, status int, cause error) {
+	writeJSONResponse(writer, status, apiErrorResponse{Error: cause.Error()})
+}
@@ -0,0 +1,89 @@
+// cmd/schedule/api_test.go
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"SummerCamp25/planner"
+)
+
+// testAPIServer answers with the sessions and a one-child want file.
+func testAPIServer(t *testing.T, sessions []planner.Session) *apiServer {
+	t.Helper()
+	want, wantError := loadWantFile(writeTestFile(t, "want.csv", "Activity,Alice's Age,Alice's Priority\nArt,8,High\n"), planner.DefaultPriorityScale())
+	if wantError != nil {
+		t.Fatal(wantError)
+	}
+	return &apiServer{
+		values:   registerPlannerFlags(flag.NewFlagSet(apiCommandLiteral, flag.ContinueOnError)),
+		scale:    planner.DefaultPriorityScale(),
+		aliases:  map[string]string{},
+		sessions: sessions,
+		want:     &want,
+	}
+}
+
+func TestAPIPlanRejectsOutOfRangeOverrides(t *testing.T) {
+	server := testAPIServer(t, []planner.Session{testSession("Art", "2025-06-16", "09:00", "12:00")})
+	tests := []struct {
+		name      string
+		options   string
+		wantError string
+	}{
+		{name: "negative buffer", options: `{"bufferMinutes": -1}`, wantError: "bufferMinutes -1 is not between 0 and 1440"},
+		{name: "buffer longer than a day", options: `{"bufferMinutes": 1441}`, wantError: "bufferMinutes 1441 is not between 0 and 1440"},
+		{name: "negative togetherness bonus", options: `{"togetherBonus": -2}`, wantError: "togetherBonus -2 is below 0"},
+		{name: "negative blackout tolerance", options: `{"blackoutToleranceDays": -1}`, wantError: "blackoutToleranceDays -1 is below 0"},
+		{name: "negative travel time", options: `{"travelMinutes": {"Pool": -5}}`, wantError: `travelMinutes["Pool"] -5 is not between 0 and 1440`},
+		{name: "unknown driver weekday", options: `{"drivers": "Funday:1"}`, wantError: "Funday"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response := serveRequest(t, server.handlePlan, http.MethodPost, json.RawMessage(`{"options": `+test.options+`}`))
+			if response.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", response.Code, http.StatusBadRequest)
+			}
+			var apiError apiErrorResponse
+			if decodeError := json.NewDecoder(response.Body).Decode(&apiError); decodeError != nil {
+				t.Fatal(decodeError)
+			}
+			if !strings.Contains(apiError.Error, test.wantError) {
+				t.Errorf("error = %q, want %s", apiError.Error, test.wantError)
+			}
+		})
+	}
+}
+
+func TestAPIPlanAcceptsOverridesInRange(t *testing.T) {
+	server := testAPIServer(t, []planner.Session{testSession("Art", "2025-06-16", "09:00", "12:00")})
+	body := json.RawMessage(`{"options": {"bufferMinutes": 0, "togetherBonus": 4, "blackoutToleranceDays": 2, "travelMinutes": {"Pool": 20}}}`)
+	response := serveRequest(t, server.handlePlan, http.MethodPost, body)
+	if response.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", response.Code, response.Body)
+	}
+	var plan planAPIResponse
+	if decodeError := json.NewDecoder(response.Body).Decode(&plan); decodeError != nil {
+		t.Fatal(decodeError)
+	}
+	if len(plan.Schedule.Joint) != 1 || plan.Schedule.Joint[0].Activity != "Art" {
+		t.Errorf("schedule = %+v, want Art", plan.Schedule)
+	}
+}
+
+func TestAPIPlanNeedsAWantFile(t *testing.T) {
+	server := testAPIServer(t, nil)
+	server.want = nil
+	if response := serveRequest(t, server.handlePlan, http.MethodPost, json.RawMessage(`{}`)); response.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", response.Code, http.StatusBadRequest)
+	}
+	malformed := httptest.NewRecorder()
+	server.handlePlan(malformed, httptest.NewRequest(http.MethodPost, "/plan", strings.NewReader(`{"options": `)))
+	if malformed.Code != http.StatusBadRequest {
+		t.Errorf("malformed body status = %d, want %d", malformed.Code, http.StatusBadRequest)
+	}
+}
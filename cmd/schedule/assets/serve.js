@@ -0,0 +1,125 @@
+// Edits the want file through /api/want and re-plans through /api/plan.
+(function () {
+    const preferencesTable = document.getElementById('preferences');
+    const blackoutsContainer = document.getElementById('blackouts');
+    const statusText = document.getElementById('status');
+    const errorText = document.getElementById('error');
+    let current = null;
+
+    const requestJSON = async (method, path, body) => {
+        const response = await fetch(path, {
+            method,
+            headers: body ? {'Content-Type': 'application/json'} : {},
+            body: body ? JSON.stringify(body) : undefined,
+        });
+        if (!response.ok) {
+            throw new Error(await response.text());
+        }
+        return response.json();
+    };
+
+    const prioritySelect = (priorities, value) => {
+        const select = document.createElement('select');
+        const options = [''].concat(priorities);
+        if (value && !options.some(option => option.toLowerCase() === value.toLowerCase())) {
+            options.push(value);
+        }
+        options.forEach(option => {
+            const element = document.createElement('option');
+            element.value = option;
+            element.textContent = option;
+            element.selected = option.toLowerCase() === (value || '').toLowerCase();
+            select.appendChild(element);
+        });
+        return select;
+    };
+
+    const showWant = data => {
+        current = data;
+        const children = data.want.children || [];
+
+        preferencesTable.innerHTML = '';
+        const header = preferencesTable.insertRow();
+        header.appendChild(document.createElement('th')).textContent = 'Activity';
+        children.forEach(child => {
+            header.appendChild(document.createElement('th')).textContent = child.name;
+        });
+        data.titles.forEach(title => {
+            const row = preferencesTable.insertRow();
+            row.insertCell().textContent = title;
+            children.forEach(child => {
+                const select = prioritySelect(data.priorities, (child.preferences || {})[title]);
+                select.dataset.child = child.name;
+                select.dataset.title = title;
+                row.insertCell().appendChild(select);
+            });
+        });
+
+        blackoutsContainer.innerHTML = '';
+        children.forEach(child => {
+            const label = document.createElement('label');
+            label.textContent = child.name;
+            const textarea = document.createElement('textarea');
+            textarea.dataset.child = child.name;
+            textarea.value = (child.blackouts || []).map(blackout => [blackout.from, blackout.to, blackout.note || ''].join(' ').trim()).join('\n');
+            label.appendChild(textarea);
+            blackoutsContainer.appendChild(label);
+        });
+    };
+
+    // editedWant applies the table and blackout edits to the loaded want file.
+    const editedWant = () => {
+        const want = JSON.parse(JSON.stringify(current.want));
+        (want.children || []).forEach(child => {
+            child.preferences = {};
+            preferencesTable.querySelectorAll('select[data-child="' + CSS.escape(child.name) + '"]').forEach(select => {
+                if (select.value) {
+                    child.preferences[select.dataset.title] = select.value;
+                }
+            });
+            const textarea = blackoutsContainer.querySelector('textarea[data-child="' + CSS.escape(child.name) + '"]');
+            child.blackouts = textarea.value.split('\n').map(line => line.trim()).filter(line => line).map(line => {
+                const [from, to, ...note] = line.split(/\s+/);
+                return {from, to: to || from, note: note.join(' ')};
+            });
+        });
+        return want;
+    };
+
+    const showPlan = plan => {
+        errorText.textContent = plan.error || '';
+        showTimeline(plan.schedule);
+        const rejections = document.getElementById('rejections');
+        rejections.innerHTML = '';
+        (plan.schedule.rejections || []).forEach(explanation => {
+            rejections.appendChild(document.createElement('li')).textContent = explanation;
+        });
+        document.getElementById('gaps').textContent = plan.gaps;
+    };
+
+    const plan = async () => {
+        statusText.textContent = 'Saving…';
+        errorText.textContent = '';
+        try {
+            const saved = await requestJSON('PUT', '/api/want', editedWant());
+            showWant(saved);
+            statusText.textContent = 'Planning…';
+            showPlan(await requestJSON('POST', '/api/plan'));
+            statusText.textContent = 'Saved to ' + saved.savedTo + ', planned at ' + new Date().toLocaleTimeString();
+        } catch (error) {
+            statusText.textContent = '';
+            errorText.textContent = error.message;
+        }
+    };
+
+    document.getElementById('plan').onclick = plan;
+    requestJSON('GET', '/api/want')
+        .then(data => {
+            showWant(data);
+            return requestJSON('POST', '/api/plan');
+        })
+        .then(showPlan)
+        .catch(error => {
+            errorText.textContent = error.message;
+        });
+})();
@@ -0,0 +1,236 @@
+// showTimeline renders a schedule as one row per group, either as multi-week bars
+// or as one bar per meeting day placed by clock time. Calling it again replaces
+// the previous rendering.
+function showTimeline(schedule) {
+    const dayMilliseconds = 24 * 60 * 60 * 1000;
+    const laneHeight = 28;
+    const dayWidth = 144;
+    const visibleDayStartMinutes = 7 * 60;
+    const visibleDayEndMinutes = 19 * 60;
+    const weekdayNames = ['Sun', 'Mon', 'Tue', 'Wed', 'Thu', 'Fri', 'Sat'];
+    const legends = {
+        slot: [['slot-allday', 'all-day'], ['slot-morning', 'morning'], ['slot-afternoon', 'afternoon']],
+        priority: [['priority-must', 'must'], ['priority-high', 'high'], ['priority-medium', 'medium'], ['priority-low', 'low']],
+    };
+
+    const container = document.getElementById('timeline');
+    const viewSelect = document.getElementById('view');
+    const colourSelect = document.getElementById('colour');
+    const unchosenToggle = document.getElementById('unchosen');
+    const legend = document.getElementById('legend');
+
+    const parseDate = text => new Date(text + 'T00:00:00');
+    const clockMinutes = text => {
+        const parts = (text || '').split(':');
+        return parts.length === 2 ? Number(parts[0]) * 60 + Number(parts[1]) : null;
+    };
+
+    const slotClass = session => {
+        const start = clockMinutes(session.startTime);
+        const end = clockMinutes(session.endTime);
+        if (start === null || end === null) {
+            return 'slot-allday';
+        }
+        if (end <= 13 * 60) {
+            return 'slot-morning';
+        }
+        return start >= 12 * 60 ? 'slot-afternoon' : 'slot-allday';
+    };
+
+    // The level comes from the priority scale the schedule was planned with.
+    const priorityClass = session => 'priority-' + (session.priorityLevel || 'low');
+
+    const describe = session => {
+        const details = [session.activity, session.startDate + ' – ' + session.endDate];
+        if (session.startTime) {
+            details.push((session.days || []).join(',') + ' ' + session.startTime + '–' + session.endTime);
+        }
+        ['location', 'price', 'availability'].forEach(field => {
+            if (session[field]) {
+                details.push(session[field]);
+            }
+        });
+        if ((session.categories || []).length > 0) {
+            details.push(session.categories.join(', '));
+        }
+        const priorities = Object.entries(session.priorities || {}).map(([child, value]) => child + ': ' + value);
+        if (priorities.length > 0) {
+            details.push('priority ' + priorities.join(', '));
+        }
+        if ((session.with || []).length > 0) {
+            details.push('with ' + session.with.join(', '));
+        }
+        if (session.overbooked) {
+            details.push('overbooked: ' + session.spotsLeft + ' spots left');
+        } else if (session.spotsLeft !== undefined) {
+            details.push(session.spotsLeft + ' spots left');
+        }
+        if ((session.missedDates || []).length > 0) {
+            details.push('misses ' + session.missedDates.join(', '));
+        }
+        return details.join('\n');
+    };
+
+    // meetings lists [start, end] timestamps of every day the session meets.
+    const meetings = session => {
+        const result = [];
+        const start = clockMinutes(session.startTime) ?? visibleDayStartMinutes;
+        const end = clockMinutes(session.endTime) ?? visibleDayEndMinutes;
+        const missed = new Set(session.missedDates || []);
+        for (let day = parseDate(session.startDate).getTime(); day <= parseDate(session.endDate).getTime(); day += dayMilliseconds) {
+            const date = new Date(day);
+            const isoDate = date.getFullYear() + '-' + String(date.getMonth() + 1).padStart(2, '0') + '-' + String(date.getDate()).padStart(2, '0');
+            if ((session.days || []).length > 0 && !session.days.includes(weekdayNames[date.getDay()])) {
+                continue;
+            }
+            if (!missed.has(isoDate)) {
+                result.push([day + start * 60000, day + end * 60000]);
+            }
+        }
+        return result;
+    };
+
+    const groupsFor = showUnchosen => {
+        const groups = [];
+        if ((schedule.joint || []).length > 0) {
+            groups.push({name: 'Joint', className: 'joint', entries: schedule.joint.map(session => ({session, unchosen: false}))});
+        }
+        const children = new Set(Object.keys(schedule.children || {}));
+        Object.keys(schedule.commitments || {}).forEach(child => children.add(child));
+        if (showUnchosen) {
+            Object.keys(schedule.candidates || {}).forEach(child => children.add(child));
+        }
+        Array.from(children).sort().forEach(child => {
+            const entries = ((schedule.children || {})[child] || []).map(session => ({session, unchosen: false}));
+            if (showUnchosen) {
+                ((schedule.candidates || {})[child] || []).forEach(session => entries.push({session, unchosen: true}));
+            }
+            ((schedule.commitments || {})[child] || []).forEach(session => entries.push({session, commitment: true}));
+            groups.push({name: child, className: 'child', entries});
+        });
+        return groups;
+    };
+
+    // placeBars positions [start, end, entry] spans into non-overlapping lanes.
+    const placeBars = (track, spans, position) => {
+        const laneEnds = [];
+        spans.sort((a, b) => a[0] - b[0]).forEach(([start, end, entry]) => {
+            let lane = laneEnds.findIndex(laneEnd => laneEnd <= start);
+            if (lane < 0) {
+                lane = laneEnds.length;
+                laneEnds.push(end);
+            } else {
+                laneEnds[lane] = end;
+            }
+            const session = entry.session;
+            const bar = document.createElement(session.url ? 'a' : 'div');
+            bar.className = 'bar ' + (entry.commitment ? 'commitment' : colourSelect.value === 'priority' ? priorityClass(session) : slotClass(session)) + (entry.unchosen ? ' unchosen' : '');
+            if (session.url) {
+                bar.href = session.url;
+                bar.target = '_blank';
+                bar.rel = 'noopener';
+            }
+            const [left, width] = position(start, end);
+            bar.style.left = left;
+            bar.style.width = width;
+            bar.style.top = (2 + lane * laneHeight) + 'px';
+            bar.textContent = session.activity;
+            bar.title = describe(session) + (entry.unchosen ? '\nnot chosen' : '') + (entry.commitment ? '\nnot camp' : '');
+            track.appendChild(bar);
+        });
+        track.style.height = (Math.max(laneEnds.length, 1) * laneHeight + 4) + 'px';
+    };
+
+    const render = () => {
+        container.innerHTML = '';
+        legend.innerHTML = '';
+        legends[colourSelect.value].forEach(([className, text]) => {
+            const swatch = document.createElement('span');
+            swatch.className = 'swatch ' + className;
+            swatch.textContent = text;
+            legend.appendChild(swatch);
+        });
+
+        const groups = groupsFor(unchosenToggle.checked);
+        const allSessions = groups.flatMap(group => group.entries.map(entry => entry.session));
+        if (allSessions.length === 0) {
+            container.innerHTML = '<p class="empty">No sessions scheduled.</p>';
+            return;
+        }
+
+        let first = Math.min(...allSessions.map(session => parseDate(session.startDate).getTime()));
+        const last = Math.max(...allSessions.map(session => parseDate(session.endDate).getTime())) + dayMilliseconds;
+        first -= ((new Date(first).getDay() + 6) % 7) * dayMilliseconds;
+        const span = last - first;
+        const hourly = viewSelect.value === 'hours';
+
+        let position;
+        const ticks = [];
+        if (hourly) {
+            const visibleMilliseconds = (visibleDayEndMinutes - visibleDayStartMinutes) * 60000;
+            const pixelsAt = time => {
+                const dayIndex = Math.floor((time - first) / dayMilliseconds);
+                const withinDay = (time - first) - dayIndex * dayMilliseconds - visibleDayStartMinutes * 60000;
+                return dayIndex * dayWidth + Math.min(Math.max(withinDay / visibleMilliseconds, 0), 1) * dayWidth;
+            };
+            position = (start, end) => [pixelsAt(start) + 'px', Math.max(pixelsAt(end) - pixelsAt(start), 4) + 'px'];
+            for (let day = first; day < last; day += dayMilliseconds) {
+                const date = new Date(day);
+                ticks.push([pixelsAt(day + visibleDayStartMinutes * 60000) + 'px', weekdayNames[date.getDay()] + ' ' + date.getDate()]);
+            }
+        } else {
+            const percentOf = time => ((time - first) / span * 100) + '%';
+            position = (start, end) => [percentOf(start), ((end - start) / span * 100) + '%'];
+            for (let week = first; week < last; week += 7 * dayMilliseconds) {
+                ticks.push([percentOf(week), new Date(week).toLocaleDateString(undefined, {month: 'short', day: 'numeric'})]);
+            }
+        }
+        const trackWidth = hourly ? (Math.round(span / dayMilliseconds) * dayWidth) + 'px' : '';
+
+        const axis = document.createElement('div');
+        axis.className = 'axis';
+        axis.innerHTML = '<div class="label"></div>';
+        const axisTrack = document.createElement('div');
+        axisTrack.className = 'track';
+        axisTrack.style.width = trackWidth;
+        ticks.forEach(([left, text]) => {
+            const tick = document.createElement('div');
+            tick.className = 'week';
+            tick.style.left = left;
+            tick.textContent = text;
+            axisTrack.appendChild(tick);
+        });
+        axis.appendChild(axisTrack);
+        container.appendChild(axis);
+
+        groups.forEach(group => {
+            const row = document.createElement('div');
+            row.className = 'row ' + group.className;
+            const label = document.createElement('div');
+            label.className = 'label';
+            label.textContent = group.name;
+            const track = document.createElement('div');
+            track.className = 'track';
+            track.style.width = trackWidth;
+
+            const spans = [];
+            group.entries.forEach(entry => {
+                if (hourly) {
+                    meetings(entry.session).forEach(([start, end]) => spans.push([start, end, entry]));
+                } else {
+                    spans.push([parseDate(entry.session.startDate).getTime(), parseDate(entry.session.endDate).getTime() + dayMilliseconds, entry]);
+                }
+            });
+            placeBars(track, spans, position);
+
+            row.appendChild(label);
+            row.appendChild(track);
+            container.appendChild(row);
+        });
+    };
+
+    [viewSelect, colourSelect, unchosenToggle].forEach(control => {
+        control.onchange = render;
+    });
+    render();
+}
@@ -0,0 +1,98 @@
+// cmd/schedule/blackout.go
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"SummerCamp25/planner"
+)
+
+const (
+	blackoutChildColumnLiteral = "child"
+	blackoutFromColumnLiteral  = "from"
+	blackoutToColumnLiteral    = "to"
+	blackoutNoteColumnLiteral  = "note"
+)
+
+// loadBlackoutFile parses a blackout CSV with Child,From,To[,Note] columns.
+// A Child of "*" or an empty cell marks a family-wide blackout; any other
+// name must be a known child.
+func loadBlackoutFile(blackoutCSVPath string, childNames []string) (map[string][]planner.Blackout, error) {
+	fileHandle, openError := os.Open(blackoutCSVPath)
+	if openError != nil {
+		return nil, openError
+	}
+	defer fileHandle.Close()
+
+	csvReader := csv.NewReader(fileHandle)
+	csvReader.FieldsPerRecord = -1
+	headerRow, headerError := csvReader.Read()
+	if headerError != nil {
+		return nil, headerError
+	}
+	columnIndexByName := map[string]int{}
+	for columnIndex, headerValue := range headerRow {
+		columnIndexByName[strings.ToLower(strings.TrimSpace(headerValue))] = columnIndex
+	}
+	for _, requiredColumn := range []string{blackoutChildColumnLiteral, blackoutFromColumnLiteral, blackoutToColumnLiteral} {
+		if _, present := columnIndexByName[requiredColumn]; !present {
+			return nil, fmt.Errorf("%s: missing %q column", blackoutCSVPath, requiredColumn)
+		}
+	}
+
+	rangesByChild := map[string][]planner.Blackout{}
+	for rowNumber := 2; ; rowNumber++ {
+		row, readError := csvReader.Read()
+		if readError == io.EOF {
+			break
+		}
+		if readError != nil {
+			return nil, readError
+		}
+		cell := func(column string) string {
+			if index, present := columnIndexByName[column]; present && index < len(row) {
+				return strings.TrimSpace(row[index])
+			}
+			return emptyLiteral
+		}
+
+		fromDate, fromError := time.Parse(dateLayoutISOLiteral, cell(blackoutFromColumnLiteral))
+		if fromError != nil {
+			return nil, fmt.Errorf("%s row %d: invalid from date %q", blackoutCSVPath, rowNumber, cell(blackoutFromColumnLiteral))
+		}
+		toDate, toError := time.Parse(dateLayoutISOLiteral, cell(blackoutToColumnLiteral))
+		if toError != nil {
+			return nil, fmt.Errorf("%s row %d: invalid to date %q", blackoutCSVPath, rowNumber, cell(blackoutToColumnLiteral))
+		}
+		if toDate.Before(fromDate) {
+			return nil, fmt.Errorf("%s row %d: blackout ends before it starts", blackoutCSVPath, rowNumber)
+		}
+		blackout := planner.Blackout{FromDate: fromDate, ToDate: toDate, Note: cell(blackoutNoteColumnLiteral)}
+
+		childName := cell(blackoutChildColumnLiteral)
+		if childName == allChildrenWildcardLiteral || childName == emptyLiteral {
+			for _, name := range childNames {
+				rangesByChild[name] = append(rangesByChild[name], blackout)
+			}
+			continue
+		}
+		if !isKnownChild(childNames, childName) {
+			return nil, fmt.Errorf("%s row %d: unknown child %q", blackoutCSVPath, rowNumber, childName)
+		}
+		rangesByChild[childName] = append(rangesByChild[childName], blackout)
+	}
+	return rangesByChild, nil
+}
+
+func formatDates(dates []time.Time) []string {
+	formatted := make([]string, 0, len(dates))
+	for _, date := range dates {
+		formatted = append(formatted, date.Format(dateLayoutISOLiteral))
+	}
+	return formatted
+}
@@ -0,0 +1,51 @@
+// cmd/schedule/blackout_test.go
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadBlackoutFile(t *testing.T) {
+	tests := []struct {
+		name      string
+		csv       string
+		want      map[string]int
+		wantError string
+	}{
+		{
+			name: "star and empty rows black out the whole family",
+			csv:  "Child,From,To,Note\n*,2025-07-01,2025-07-04,Lake\n,2025-08-01,2025-08-01\nAlice,2025-07-14,2025-07-18,Grandma\n",
+			want: map[string]int{"Alice": 3, "Bob": 2},
+		},
+		{
+			name:      "an unknown child is an error",
+			csv:       "Child,From,To\nAlicia,2025-07-01,2025-07-04\n",
+			wantError: `row 2: unknown child "Alicia"`,
+		},
+		{
+			name:      "a blackout ending before it starts is an error",
+			csv:       "Child,From,To\nAlice,2025-07-04,2025-07-01\n",
+			wantError: "row 2: blackout ends before it starts",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rangesByChild, loadError := loadBlackoutFile(writeTestFile(t, "blackouts.csv", test.csv), []string{"Alice", "Bob"})
+			if test.wantError != emptyLiteral {
+				if loadError == nil || !strings.Contains(loadError.Error(), test.wantError) {
+					t.Fatalf("error = %v, want one containing %q", loadError, test.wantError)
+				}
+				return
+			}
+			if loadError != nil {
+				t.Fatal(loadError)
+			}
+			for childName, count := range test.want {
+				if len(rangesByChild[childName]) != count {
+					t.Errorf("%s has %d blackouts, want %d", childName, len(rangesByChild[childName]), count)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// cmd/schedule/categories.go
+package main
+
+import (
+	"fmt"
+	"regexp"
+
+	"SummerCamp25/planner"
+)
+
+const (
+	flagCategoriesParameterNameLiteral      = "categories"
+	flagCategoriesParameterUsageLiteral     = "path to categories CSV (Pattern,Category) tagging sessions whose title matches the case-insensitive pattern"
+	flagDiversityBonusParameterNameLiteral  = "diversity-bonus"
+	flagDiversityBonusParameterUsageLiteral = "score added for each category a child gets a first session of"
+	categoryPatternColumnLiteral            = "pattern"
+	categoryCategoryColumnLiteral           = "category"
+	caseInsensitivePrefixLiteral            = "(?i)"
+)
+
+// loadCategoriesFile reads Pattern,Category rows into a tag table, in file order.
+func loadCategoriesFile(categoriesCSVPath string) (planner.CategoryTable, error) {
+	rows, readError := readCSVColumns(categoriesCSVPath, categoryPatternColumnLiteral, categoryCategoryColumnLiteral)
+	if readError != nil {
+		return nil, readError
+	}
+	var table planner.CategoryTable
+	for rowIndex, row := range rows {
+		if row[0] == emptyLiteral || row[1] == emptyLiteral {
+			return nil, fmt.Errorf("%s row %d: empty pattern or category", categoriesCSVPath, rowIndex+2)
+		}
+		pattern, compileError := regexp.Compile(caseInsensitivePrefixLiteral + row[0])
+		if compileError != nil {
+			return nil, fmt.Errorf("%s row %d: %w", categoriesCSVPath, rowIndex+2, compileError)
+		}
+		table = append(table, planner.CategoryRule{Pattern: pattern, Category: row[1]})
+	}
+	return table, nil
+}
+
+func (values plannerFlags) loadCategories() (planner.CategoryTable, error) {
+	if *values.categoriesPath == emptyLiteral {
+		return nil, nil
+	}
+	return loadCategoriesFile(*values.categoriesPath)
+}
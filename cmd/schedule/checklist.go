@@ -0,0 +1,85 @@
+// cmd/schedule/checklist.go
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"SummerCamp25/planner"
+)
+
+const (
+	flagChecklistParameterNameLiteral  = "checklist"
+	flagChecklistParameterUsageLiteral = "write a registration checklist, grouped by when registration opens, to this file"
+	checklistHeadingLiteral            = "Registration checklist"
+	checklistOpensFormat               = "Opens %s\n"
+	checklistOpensLayout               = "Mon 2006-01-02 15:04"
+	checklistUnknownOpeningLiteral     = "Opening time unknown"
+	checklistItemFormat                = "  %d. [ ] %s\n       %s\n"
+	checklistBackupFormat              = "       backup %s\n         %s\n"
+	checklistFieldSeparatorLiteral     = " · "
+	checklistNumberPrefixLiteral       = "#"
+)
+
+// writeChecklistFile saves the registration checklist of a finished plan.
+func writeChecklistFile(outputPath string, result *planner.Result, preferences planner.Preferences) error {
+	fileHandle, createError := os.Create(outputPath)
+	if createError != nil {
+		return createError
+	}
+	defer fileHandle.Close()
+
+	writeChecklist(fileHandle, result, preferences)
+	fmt.Println(outputWrittenPrefixLiteral, outputPath)
+	return nil
+}
+
+// writeChecklist prints one numbered block per registration opening moment,
+// in the order to click through, each item followed by its link and backups.
+func writeChecklist(writer io.Writer, result *planner.Result, preferences planner.Preferences) {
+	fmt.Fprintln(writer, checklistHeadingLiteral)
+	fmt.Fprintln(writer)
+	for _, group := range result.RegistrationChecklist(preferences) {
+		if group.Opens.IsZero() {
+			fmt.Fprintln(writer, checklistUnknownOpeningLiteral)
+		} else {
+			fmt.Fprintf(writer, checklistOpensFormat, group.Opens.Format(checklistOpensLayout))
+		}
+		for itemIndex, item := range group.Items {
+			fmt.Fprintf(writer, checklistItemFormat, itemIndex+1, checklistItemSummary(item, preferences), item.Session.Link())
+			for _, backup := range result.BackupsFor(item.Session, item.ChildNames) {
+				backupItem := planner.RegistrationItem{Session: backup, ChildNames: item.ChildNames}
+				fmt.Fprintf(writer, checklistBackupFormat, checklistItemSummary(backupItem, preferences), backup.Link())
+			}
+		}
+		fmt.Fprintln(writer)
+	}
+}
+
+// checklistItemSummary lists children, activity number, title, dates, fee,
+// each child's priority and the availability seen when scraping.
+func checklistItemSummary(item planner.RegistrationItem, preferences planner.Preferences) string {
+	session := item.Session
+	fields := []string{strings.Join(item.ChildNames, siblingSeparatorLiteral)}
+	if session.ActivityNumber != emptyLiteral {
+		fields = append(fields, checklistNumberPrefixLiteral+session.ActivityNumber)
+	}
+	fields = append(fields, session.ActivityName, session.StartDate().Format(dateLayoutISOLiteral)+" – "+session.EndDate().Format(dateLayoutISOLiteral))
+	if session.Price != emptyLiteral {
+		fields = append(fields, session.Price)
+	}
+	var priorities []string
+	for _, childName := range item.ChildNames {
+		priorities = append(priorities, childName+" "+preferences.PriorityOf(childName, session))
+	}
+	fields = append(fields, strings.Join(priorities, siblingSeparatorLiteral))
+	if session.AvailabilityText != emptyLiteral {
+		fields = append(fields, session.AvailabilityText)
+	}
+	if spots, known := session.SpotsLeft(); known && len(item.ChildNames) > spots {
+		fields = append(fields, strings.TrimSpace(spotsSuffix(session, len(item.ChildNames))))
+	}
+	return strings.Join(fields, checklistFieldSeparatorLiteral)
+}
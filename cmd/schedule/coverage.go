@@ -0,0 +1,117 @@
+// cmd/schedule/coverage.go
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"SummerCamp25/planner"
+)
+
+const (
+	coverageChildColumnLiteral = "child"
+	coverageDaysColumnLiteral  = "days"
+	coverageStartColumnLiteral = "start"
+	coverageEndColumnLiteral   = "end"
+	coverageFromColumnLiteral  = "from"
+	coverageToColumnLiteral    = "to"
+	coverageNoteColumnLiteral  = "note"
+	allChildrenWildcardLiteral = "*"
+)
+
+// loadCoverageFile parses a coverage CSV with Child,Days,Start,End,From,To columns.
+// A Child of "*" applies the window to every child; any other name must be a known child.
+func loadCoverageFile(coverageCSVPath string, childNames []string) (map[string][]planner.WeeklyWindow, error) {
+	blocksByChild, loadError := loadWindowFile(coverageCSVPath, childNames)
+	if loadError != nil {
+		return nil, loadError
+	}
+	windowsByChild := map[string][]planner.WeeklyWindow{}
+	for childName, blocks := range blocksByChild {
+		for _, block := range blocks {
+			windowsByChild[childName] = append(windowsByChild[childName], block.WeeklyWindow)
+		}
+	}
+	return windowsByChild, nil
+}
+
+// loadCommitmentsFile parses a commitments CSV laid out like the coverage CSV
+// plus a Note column naming the commitment. A misspelled child is an error
+// rather than a commitment the overlap check never sees.
+func loadCommitmentsFile(commitmentsCSVPath string, childNames []string) (map[string][]planner.Commitment, error) {
+	return loadWindowFile(commitmentsCSVPath, childNames)
+}
+
+// loadWindowFile reads Child,Days,Start,End,From,To[,Note] rows per child.
+func loadWindowFile(windowCSVPath string, childNames []string) (map[string][]planner.Commitment, error) {
+	fileHandle, openError := os.Open(windowCSVPath)
+	if openError != nil {
+		return nil, openError
+	}
+	defer fileHandle.Close()
+
+	csvReader := csv.NewReader(fileHandle)
+	csvReader.FieldsPerRecord = -1
+	headerRow, headerError := csvReader.Read()
+	if headerError != nil {
+		return nil, headerError
+	}
+	columnIndexByName := map[string]int{}
+	for columnIndex, headerValue := range headerRow {
+		columnIndexByName[strings.ToLower(strings.TrimSpace(headerValue))] = columnIndex
+	}
+	for _, requiredColumn := range []string{coverageChildColumnLiteral, coverageDaysColumnLiteral, coverageStartColumnLiteral, coverageEndColumnLiteral, coverageFromColumnLiteral, coverageToColumnLiteral} {
+		if _, present := columnIndexByName[requiredColumn]; !present {
+			return nil, fmt.Errorf("%s: missing %q column", windowCSVPath, requiredColumn)
+		}
+	}
+
+	blocksByChild := map[string][]planner.Commitment{}
+	for rowNumber := 2; ; rowNumber++ {
+		row, readError := csvReader.Read()
+		if readError == io.EOF {
+			break
+		}
+		if readError != nil {
+			return nil, readError
+		}
+		cell := func(column string) string {
+			if index, present := columnIndexByName[column]; present && index < len(row) {
+				return strings.TrimSpace(row[index])
+			}
+			return emptyLiteral
+		}
+
+		parsedWindow, windowError := planner.ParseWeeklyWindow(cell(coverageDaysColumnLiteral), cell(coverageStartColumnLiteral), cell(coverageEndColumnLiteral), cell(coverageFromColumnLiteral), cell(coverageToColumnLiteral))
+		if windowError != nil {
+			return nil, fmt.Errorf("%s row %d: %w", windowCSVPath, rowNumber, windowError)
+		}
+		block := planner.Commitment{WeeklyWindow: parsedWindow, Note: cell(coverageNoteColumnLiteral)}
+
+		childName := cell(coverageChildColumnLiteral)
+		if childName == allChildrenWildcardLiteral || childName == emptyLiteral {
+			for _, name := range childNames {
+				blocksByChild[name] = append(blocksByChild[name], block)
+			}
+			continue
+		}
+		if !isKnownChild(childNames, childName) {
+			return nil, fmt.Errorf("%s row %d: unknown child %q", windowCSVPath, rowNumber, childName)
+		}
+		blocksByChild[childName] = append(blocksByChild[childName], block)
+	}
+	return blocksByChild, nil
+}
+
+// isKnownChild reports whether a per-child file names one of the planned children.
+func isKnownChild(childNames []string, childName string) bool {
+	for _, name := range childNames {
+		if name == childName {
+			return true
+		}
+	}
+	return false
+}
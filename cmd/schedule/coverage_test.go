@@ -0,0 +1,100 @@
+// cmd/schedule/coverage_test.go
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"SummerCamp25/planner"
+)
+
+func TestLoadCoverageFile(t *testing.T) {
+	tests := []struct {
+		name      string
+		csv       string
+		want      map[string][]string
+		wantError string
+	}{
+		{
+			name: "a star row applies to every child",
+			csv:  "Child,Days,Start,End,From,To\n*,Mon-Fri,08:00,17:00,2025-06-16,2025-08-22\nAlice,Sat,09:00,12:00,2025-06-16,2025-08-22\n",
+			want: map[string][]string{
+				"Alice": {"Mon,Tue,Wed,Thu,Fri 480-1020", "Sat 540-720"},
+				"Bob":   {"Mon,Tue,Wed,Thu,Fri 480-1020"},
+			},
+		},
+		{
+			name: "a day range wraps past Sunday",
+			csv:  "Child,Days,Start,End,From,To\nBob,Fri-Mon,08:00,17:00,2025-06-16,2025-08-22\n",
+			want: map[string][]string{"Bob": {"Mon,Fri,Sat,Sun 480-1020"}},
+		},
+		{
+			name:      "an unknown child is an error",
+			csv:       "Child,Days,Start,End,From,To\nAlicia,Mon-Fri,08:00,17:00,2025-06-16,2025-08-22\n",
+			wantError: `row 2: unknown child "Alicia"`,
+		},
+		{
+			name:      "a window ending before it starts is an error",
+			csv:       "Child,Days,Start,End,From,To\nAlice,Mon-Fri,17:00,08:00,2025-06-16,2025-08-22\n",
+			wantError: "row 2: window 17:00-08:00 ends before it starts",
+		},
+		{
+			name:      "a missing column is an error",
+			csv:       "Child,Days,Start,End,From\nAlice,Mon-Fri,08:00,17:00,2025-06-16\n",
+			wantError: `missing "to" column`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			windowsByChild, loadError := loadCoverageFile(writeTestFile(t, "coverage.csv", test.csv), []string{"Alice", "Bob"})
+			if test.wantError != emptyLiteral {
+				if loadError == nil || !strings.Contains(loadError.Error(), test.wantError) {
+					t.Fatalf("error = %v, want one containing %q", loadError, test.wantError)
+				}
+				return
+			}
+			if loadError != nil {
+				t.Fatal(loadError)
+			}
+			for childName, want := range test.want {
+				var got []string
+				for _, window := range windowsByChild[childName] {
+					got = append(got, fmt.Sprintf("%s %d-%d", planner.FormatWeekdays(window.Weekdays), window.StartClockMinutes, window.EndClockMinutes))
+				}
+				if !slices.Equal(got, want) {
+					t.Errorf("%s windows = %q, want %q", childName, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadCommitmentsFile(t *testing.T) {
+	commitmentsCSV := "Child,Days,Start,End,From,To,Note\n" +
+		"Alice,Tue,13:00,14:00,2025-06-16,2025-08-22,Swim team\n" +
+		"*,Thu,16:00,17:00,2025-06-16,2025-08-22\n"
+	commitmentsByChild, loadError := loadCommitmentsFile(writeTestFile(t, "commitments.csv", commitmentsCSV), []string{"Alice", "Bob"})
+	if loadError != nil {
+		t.Fatal(loadError)
+	}
+	describe := func(commitments []planner.Commitment) []string {
+		var described []string
+		for _, commitment := range commitments {
+			described = append(described, planner.FormatWeekdays(commitment.Weekdays)+" "+commitment.Note)
+		}
+		return described
+	}
+	if got, want := describe(commitmentsByChild["Alice"]), []string{"Tue Swim team", "Thu "}; !slices.Equal(got, want) {
+		t.Errorf("Alice's commitments = %q, want %q", got, want)
+	}
+	if got, want := describe(commitmentsByChild["Bob"]), []string{"Thu "}; !slices.Equal(got, want) {
+		t.Errorf("Bob's commitments = %q, want %q", got, want)
+	}
+
+	shortRowCSV := "Child,Days,Start,End,From,To,Note\nAlice,Tue,13:00\n"
+	if _, loadError := loadCommitmentsFile(writeTestFile(t, "commitments.csv", shortRowCSV), []string{"Alice"}); loadError == nil || !strings.Contains(loadError.Error(), "row 2") {
+		t.Errorf("error = %v, want one for row 2", loadError)
+	}
+}
@@ -0,0 +1,91 @@
+// cmd/schedule/drivers.go
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"SummerCamp25/planner"
+)
+
+const (
+	travelLocationColumnLiteral = "location"
+	travelMinutesColumnLiteral  = "minutes"
+	driverEntrySeparator        = ","
+	driverCountSeparator        = ":"
+)
+
+// loadTravelFile parses a Location,Minutes CSV of one-way travel times from home.
+func loadTravelFile(travelCSVPath string) (map[string]int, error) {
+	fileHandle, openError := os.Open(travelCSVPath)
+	if openError != nil {
+		return nil, openError
+	}
+	defer fileHandle.Close()
+
+	csvReader := csv.NewReader(fileHandle)
+	headerRow, headerError := csvReader.Read()
+	if headerError != nil {
+		return nil, headerError
+	}
+	locationIndex, minutesIndex := -1, -1
+	for columnIndex, headerValue := range headerRow {
+		switch strings.ToLower(strings.TrimSpace(headerValue)) {
+		case travelLocationColumnLiteral:
+			locationIndex = columnIndex
+		case travelMinutesColumnLiteral:
+			minutesIndex = columnIndex
+		}
+	}
+	if locationIndex < 0 || minutesIndex < 0 {
+		return nil, fmt.Errorf("%s: need %q and %q columns", travelCSVPath, travelLocationColumnLiteral, travelMinutesColumnLiteral)
+	}
+
+	minutesByLocation := map[string]int{}
+	for rowNumber := 2; ; rowNumber++ {
+		row, readError := csvReader.Read()
+		if readError == io.EOF {
+			break
+		}
+		if readError != nil {
+			return nil, readError
+		}
+		minutes, parseError := strconv.Atoi(strings.TrimSpace(row[minutesIndex]))
+		if parseError != nil || minutes < 0 {
+			return nil, fmt.Errorf("%s row %d: invalid minutes %q", travelCSVPath, rowNumber, row[minutesIndex])
+		}
+		minutesByLocation[strings.TrimSpace(row[locationIndex])] = minutes
+	}
+	return minutesByLocation, nil
+}
+
+// parseDriverCounts reads "Mon-Thu:2,Fri:1" into drivers available per weekday.
+func parseDriverCounts(driversText string) (map[string]int, error) {
+	countsByWeekday := map[string]int{}
+	for _, entry := range strings.Split(driversText, driverEntrySeparator) {
+		entry = strings.TrimSpace(entry)
+		if entry == emptyLiteral {
+			continue
+		}
+		parts := strings.Split(entry, driverCountSeparator)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid drivers entry %q, want Days:Count", entry)
+		}
+		weekdays, weekdaysError := planner.ExpandWeekdays(parts[0])
+		if weekdaysError != nil {
+			return nil, weekdaysError
+		}
+		count, countError := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if countError != nil || count < 0 {
+			return nil, fmt.Errorf("invalid driver count in %q", entry)
+		}
+		for weekday := range weekdays {
+			countsByWeekday[weekday] = count
+		}
+	}
+	return countsByWeekday, nil
+}
@@ -0,0 +1,39 @@
+// cmd/schedule/drivers_test.go
+package main
+
+import (
+	"maps"
+	"strings"
+	"testing"
+)
+
+func TestParseDriverCounts(t *testing.T) {
+	counts, parseError := parseDriverCounts("Mon-Thu:2, Fri:1")
+	if parseError != nil {
+		t.Fatal(parseError)
+	}
+	for weekday, want := range map[string]int{"Mon": 2, "Thu": 2, "Fri": 1} {
+		if counts[weekday] != want {
+			t.Errorf("%s drivers = %d, want %d", weekday, counts[weekday], want)
+		}
+	}
+	if _, constrained := counts["Sat"]; constrained {
+		t.Error("Sat has a driver count, want none")
+	}
+	if _, parseError := parseDriverCounts("Mon-Fri"); parseError == nil {
+		t.Error("an entry without a count parsed")
+	}
+}
+
+func TestLoadTravelFile(t *testing.T) {
+	minutesByLocation, loadError := loadTravelFile(writeTestFile(t, "travel.csv", "Location,Minutes\nPool,10\n Studio , 25\n"))
+	if loadError != nil {
+		t.Fatal(loadError)
+	}
+	if want := map[string]int{"Pool": 10, "Studio": 25}; !maps.Equal(minutesByLocation, want) {
+		t.Errorf("travel = %v, want %v", minutesByLocation, want)
+	}
+	if _, loadError := loadTravelFile(writeTestFile(t, "travel.csv", "Location,Minutes\nPool,-5\n")); loadError == nil || !strings.Contains(loadError.Error(), `row 2: invalid minutes "-5"`) {
+		t.Errorf("error = %v, want the negative minutes on row 2", loadError)
+	}
+}
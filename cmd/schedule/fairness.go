@@ -0,0 +1,62 @@
+// cmd/schedule/fairness.go
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"SummerCamp25/planner"
+)
+
+const (
+	flagFairnessParameterNameLiteral     = "fairness"
+	flagFairnessParameterUsageLiteral    = "\"max-min\" serves the sibling with the smallest share of wishes fulfilled first"
+	flagMaxScoreGapParameterNameLiteral  = "max-score-gap"
+	flagMaxScoreGapParameterUsageLiteral = "most priority score one child may be ahead of a sibling who can still be placed; 0 disables it"
+	satisfactionLineFormat               = "%s satisfaction %d%% (%s)\n"
+	satisfactionCountFormat              = "%s %d/%d"
+)
+
+// satisfactionJSON is one child's entry in the -json satisfaction summary.
+type satisfactionJSON struct {
+	Percent    int               `json:"percent"`
+	ByPriority map[string][2]int `json:"byPriority,omitempty"`
+}
+
+// parseFairness accepts an empty value or a known fairness name.
+func parseFairness(value string) (planner.Fairness, error) {
+	switch fairness := planner.Fairness(strings.ToLower(strings.TrimSpace(value))); fairness {
+	case planner.FairnessNone, planner.FairnessMaxMin:
+		return fairness, nil
+	default:
+		return planner.FairnessNone, fmt.Errorf("unknown fairness %q (want %q)", value, planner.FairnessMaxMin)
+	}
+}
+
+// writeSatisfaction writes "Alice satisfaction 75% (High 2/3, Medium 1/1)".
+func writeSatisfaction(writer io.Writer, result *planner.Result, childName string) {
+	satisfaction, known := result.Satisfaction[childName]
+	if !known || satisfaction.Wished == 0 {
+		return
+	}
+	var counts []string
+	for _, priority := range satisfaction.ByPriority {
+		counts = append(counts, fmt.Sprintf(satisfactionCountFormat, priority.Priority, priority.Fulfilled, priority.Wished))
+	}
+	fmt.Fprintf(writer, satisfactionLineFormat, childName, satisfaction.Percent(), strings.Join(counts, siblingSeparatorLiteral))
+}
+
+// exportSatisfaction maps each child to their share of wishes fulfilled and,
+// per priority, [fulfilled, wished].
+func exportSatisfaction(result *planner.Result) map[string]satisfactionJSON {
+	exported := map[string]satisfactionJSON{}
+	for childName, satisfaction := range result.Satisfaction {
+		entry := satisfactionJSON{Percent: satisfaction.Percent(), ByPriority: map[string][2]int{}}
+		for _, priority := range satisfaction.ByPriority {
+			entry.ByPriority[priority.Priority] = [2]int{priority.Fulfilled, priority.Wished}
+		}
+		exported[childName] = entry
+	}
+	return exported
+}
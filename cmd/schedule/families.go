@@ -0,0 +1,269 @@
+// cmd/schedule/families.go
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"SummerCamp25/planner"
+)
+
+const (
+	familiesCommandLiteral               = "families"
+	flagFamiliesParameterNameLiteral     = "families"
+	flagFamiliesParameterUsageLiteral    = "comma-separated Name=want-file pairs, one per family"
+	flagFriendsParameterNameLiteral      = "friends"
+	flagFriendsParameterUsageLiteral     = "path to friends CSV (Child,Friend); friendships go both ways"
+	flagFriendBonusParameterNameLiteral  = "friend-bonus"
+	flagFriendBonusParameterUsageLiteral = "score added for every pair of friends sharing a session"
+	flagOutputDirectoryParameterUsage    = "directory to write each family's Name.txt and Name.json into"
+	fatalFamiliesFlagsLiteral            = "FATAL: families needs -sessions, -families and -out"
+	friendsChildColumnLiteral            = "child"
+	friendsFriendColumnLiteral           = "friend"
+	familyAssignmentSeparatorLiteral     = "="
+	familyTextExtensionLiteral           = ".txt"
+	familyJSONExtensionLiteral           = ".json"
+	sharedHeadingLiteral                 = "Shared with friends"
+	sharedFriendFormat                   = "%s (%s)"
+	budgetSummaryFormat                  = "budget: $%.2f of $%.2f spent\n"
+	familiesOutputDirectoryPermissions   = 0o755
+)
+
+// familyWant is one -families entry.
+type familyWant struct {
+	name     string
+	wantPath string
+}
+
+// runFamilies plans several families at once so friends can share sessions,
+// then writes every family a report of their own. Each family's children,
+// budget and limits come from their want file; the planner flags apply to all.
+func runFamilies(arguments []string) {
+	flags := flag.NewFlagSet(familiesCommandLiteral, flag.ExitOnError)
+	values := registerPlannerFlags(flags)
+	familiesFlag := flags.String(flagFamiliesParameterNameLiteral, emptyLiteral, flagFamiliesParameterUsageLiteral)
+	friendsPathFlag := flags.String(flagFriendsParameterNameLiteral, emptyLiteral, flagFriendsParameterUsageLiteral)
+	friendBonusFlag := flags.Int(flagFriendBonusParameterNameLiteral, planner.DefaultFriendBonus, flagFriendBonusParameterUsageLiteral)
+	outputDirectoryFlag := flags.String(flagOutputParameterNameLiteral, emptyLiteral, flagOutputDirectoryParameterUsage)
+	_ = flags.Parse(arguments)
+
+	if *values.sessionsPath == emptyLiteral || *familiesFlag == emptyLiteral || *outputDirectoryFlag == emptyLiteral {
+		fmt.Println(fatalFamiliesFlagsLiteral)
+		return
+	}
+	families, familiesError := parseFamilies(*familiesFlag)
+	if familiesError != nil {
+		fmt.Println("FATAL:", familiesError)
+		return
+	}
+	scale, scaleError := values.loadScale()
+	if scaleError != nil {
+		fmt.Println("FATAL:", scaleError)
+		return
+	}
+	want, wantError := loadFamilies(families, scale)
+	if wantError != nil {
+		fmt.Println("FATAL:", wantError)
+		return
+	}
+	if *friendsPathFlag != emptyLiteral {
+		if friendsError := loadFriendsFile(*friendsPathFlag, &want); friendsError != nil {
+			fmt.Println("FATAL:", friendsError)
+			return
+		}
+	}
+	aliases, aliasError := values.loadAliases()
+	if aliasError != nil {
+		fmt.Println("FATAL:", aliasError)
+		return
+	}
+	categories, categoriesError := values.loadCategories()
+	if categoriesError != nil {
+		fmt.Println("FATAL:", categoriesError)
+		return
+	}
+	rawSessions, sessionsError := loadSessions(*values.sessionsPath)
+	if sessionsError != nil {
+		fmt.Println("FATAL:", sessionsError)
+		return
+	}
+	categories.Tag(rawSessions)
+	titleMatches, _ := matchWantTitles(want.Titles(), distinctSessionTitles(rawSessions), aliases)
+	applyTitleMatches(rawSessions, titleMatches)
+
+	options, preferences, optionsError := values.buildOptions(want)
+	if optionsError != nil {
+		fmt.Println("FATAL:", optionsError)
+		return
+	}
+	options.FriendBonus = *friendBonusFlag
+
+	result, planError := planner.New(options).Plan(context.Background(), rawSessions, preferences)
+	if planError != nil {
+		fmt.Println("FATAL:", planError)
+		return
+	}
+	if requirementError := result.RequirementsError(); requirementError != nil {
+		fmt.Println("FATAL:", requirementError)
+		return
+	}
+
+	if mkdirError := os.MkdirAll(*outputDirectoryFlag, familiesOutputDirectoryPermissions); mkdirError != nil {
+		fmt.Println("FATAL:", mkdirError)
+		return
+	}
+	for _, household := range result.Households {
+		if writeError := writeFamilyReports(*outputDirectoryFlag, rawSessions, result, household, preferences); writeError != nil {
+			fmt.Println("FATAL:", writeError)
+			return
+		}
+	}
+}
+
+// parseFamilies splits "Smith=smith.json,Jones=jones.csv".
+func parseFamilies(familiesText string) ([]familyWant, error) {
+	var families []familyWant
+	seen := map[string]struct{}{}
+	for _, entry := range strings.Split(familiesText, ",") {
+		name, wantPath, found := strings.Cut(strings.TrimSpace(entry), familyAssignmentSeparatorLiteral)
+		name, wantPath = strings.TrimSpace(name), strings.TrimSpace(wantPath)
+		if !found || name == emptyLiteral || wantPath == emptyLiteral {
+			return nil, fmt.Errorf("family %q: want Name=want-file", entry)
+		}
+		if _, duplicate := seen[name]; duplicate {
+			return nil, fmt.Errorf("duplicate family %q", name)
+		}
+		seen[name] = struct{}{}
+		families = append(families, familyWant{name: name, wantPath: wantPath})
+	}
+	return families, nil
+}
+
+// loadFamilies merges every family's want file into one set of preferences
+// with a household per family. Child names must be unique across families.
+func loadFamilies(families []familyWant, scale planner.PriorityScale) (planner.Preferences, error) {
+	merged := planner.Preferences{Priorities: map[string]map[string]string{}, Scale: scale}
+	familyByChild := map[string]string{}
+	for _, family := range families {
+		want, wantError := loadWantFile(family.wantPath, scale)
+		if wantError != nil {
+			return planner.Preferences{}, wantError
+		}
+		for _, child := range want.Children {
+			if other, taken := familyByChild[child.Name]; taken {
+				return planner.Preferences{}, fmt.Errorf("child %q is in families %q and %q; rename one", child.Name, other, family.name)
+			}
+			familyByChild[child.Name] = family.name
+			merged.Children = append(merged.Children, child)
+		}
+		for title, priorityByChild := range want.Priorities {
+			if merged.Priorities[title] == nil {
+				merged.Priorities[title] = map[string]string{}
+			}
+			for childName, priorityValue := range priorityByChild {
+				merged.Priorities[title][childName] = priorityValue
+			}
+		}
+		merged.Households = append(merged.Households, planner.Household{Name: family.name, ChildNames: want.ChildNames(), Budget: want.Budget})
+	}
+	return merged, nil
+}
+
+// loadFriendsFile reads Child,Friend rows into the children's Friends.
+func loadFriendsFile(friendsCSVPath string, want *planner.Preferences) error {
+	rows, readError := readCSVColumns(friendsCSVPath, friendsChildColumnLiteral, friendsFriendColumnLiteral)
+	if readError != nil {
+		return readError
+	}
+	for rowIndex, row := range rows {
+		for _, childName := range row {
+			if _, known := want.ChildNamed(childName); !known {
+				return fmt.Errorf("%s row %d: unknown child %q", friendsCSVPath, rowIndex+2, childName)
+			}
+		}
+		for childIndex := range want.Children {
+			if want.Children[childIndex].Name == row[0] {
+				want.Children[childIndex].Friends = append(want.Children[childIndex].Friends, row[1])
+			}
+		}
+	}
+	return nil
+}
+
+// writeFamilyReports writes the household's Name.txt and Name.json. Other
+// families appear only as friends in the sessions they share.
+func writeFamilyReports(outputDirectory string, allSessions []planner.Session, result *planner.Result, household planner.Household, preferences planner.Preferences) error {
+	view := result.ForHousehold(household)
+	textHandle, createError := os.Create(filepath.Join(outputDirectory, household.Name+familyTextExtensionLiteral))
+	if createError != nil {
+		return createError
+	}
+	defer textHandle.Close()
+	writeTextOutput(textHandle, view)
+	writeBudgetSummary(textHandle, view)
+	writeSharedSessions(textHandle, result, view)
+	writeGapReport(textHandle, allSessions, view)
+	if hasCoverage(preferences) {
+		writeCoverageSummary(textHandle, view)
+	}
+
+	exportData := buildExport(allSessions, view, preferences)
+	for _, childName := range view.ChildNames {
+		for _, session := range view.Plans[childName].Sessions {
+			if friends := friendsElsewhere(result, childName, session); len(friends) > 0 {
+				entry := exportSession(preferences, session, []string{childName})
+				entry.Friends = friends
+				exportData.Shared = append(exportData.Shared, entry)
+			}
+		}
+	}
+	fmt.Println(outputWrittenPrefixLiteral, textHandle.Name())
+	return writeJSONOutput(filepath.Join(outputDirectory, household.Name+familyJSONExtensionLiteral), exportData)
+}
+
+// writeSharedSessions lists the household's sessions a friend from another family also attends.
+func writeSharedSessions(writer io.Writer, result *planner.Result, view *planner.Result) {
+	var lines []string
+	for _, childName := range view.ChildNames {
+		for _, session := range view.Plans[childName].Sessions {
+			if friends := friendsElsewhere(result, childName, session); len(friends) > 0 {
+				lines = append(lines, fmt.Sprintln(childName, session.ActivityName, session.StartDate().Format(dateLayoutISOLiteral), session.EndDate().Format(dateLayoutISOLiteral)+
+					siblingsSuffix(friends)))
+			}
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Fprintln(writer, sharedHeadingLiteral)
+	for _, line := range lines {
+		fmt.Fprint(writer, line)
+	}
+	fmt.Fprintln(writer)
+}
+
+// friendsElsewhere names, as "Zoe (Jones)", the child's friends from other
+// households who attend the session.
+func friendsElsewhere(result *planner.Result, childName string, session planner.Session) []string {
+	household := result.HouseholdOf(childName)
+	var friends []string
+	for _, friendName := range result.FriendsSharing(childName, session) {
+		if friendHousehold := result.HouseholdOf(friendName); friendHousehold.Name != household.Name {
+			friends = append(friends, fmt.Sprintf(sharedFriendFormat, friendName, friendHousehold.Name))
+		}
+	}
+	return friends
+}
+
+// writeBudgetSummary writes what a single household's plan spends against its budget.
+func writeBudgetSummary(writer io.Writer, result *planner.Result) {
+	if len(result.Households) != 1 || result.Households[0].Budget <= 0 {
+		return
+	}
+	fmt.Fprintf(writer, budgetSummaryFormat, result.Spent(result.Households[0]), result.Households[0].Budget)
+}
@@ -0,0 +1,63 @@
+// cmd/schedule/gaps.go
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"SummerCamp25/planner"
+)
+
+const (
+	gapsHeadingSuffixLiteral = "gaps"
+	gapsWeekHeadingFormat    = "Week of %s\n"
+	gapsDayLineFormat        = "  %s %s %s%.1f hours open\n"
+	gapsTotalLineFormat      = "%s uncovered %.1f hours\n"
+	gapsCommitmentFormat     = "%s %s–%s"
+	gapsCommitmentLiteral    = "commitment"
+)
+
+// writeGapReport writes each child's open weekdays grouped by week.
+func writeGapReport(writer io.Writer, allSessions []planner.Session, result *planner.Result) {
+	firstDate, lastDate, hasSessions := planner.SummerDateRange(allSessions)
+	if !hasSessions {
+		return
+	}
+	for _, childName := range result.ChildNames {
+		fmt.Fprintln(writer, childName, gapsHeadingSuffixLiteral)
+		totalUncovered := 0
+		var currentWeekStart time.Time
+		for _, gap := range result.Plans[childName].Gaps(firstDate, lastDate) {
+			if weekStart := planner.WeekStartOf(gap.Date); !weekStart.Equal(currentWeekStart) {
+				currentWeekStart = weekStart
+				fmt.Fprintf(writer, gapsWeekHeadingFormat, weekStart.Format(dateLayoutISOLiteral))
+			}
+			var parts []string
+			if gap.Description != emptyLiteral {
+				parts = append(parts, gap.Description)
+			}
+			for _, commitment := range gap.Commitments {
+				parts = append(parts, describeCommitment(commitment))
+			}
+			description := strings.Join(parts, ", ")
+			if description != emptyLiteral {
+				description += ", "
+			}
+			fmt.Fprintf(writer, gapsDayLineFormat, planner.WeekdayAbbreviation(gap.Date), gap.Date.Format(dateLayoutISOLiteral), description, float64(gap.UncoveredMinutes)/60)
+			totalUncovered += gap.UncoveredMinutes
+		}
+		fmt.Fprintf(writer, gapsTotalLineFormat, childName, float64(totalUncovered)/60)
+		fmt.Fprintln(writer)
+	}
+}
+
+// describeCommitment is "swim team 15:00–17:00"; unnamed commitments say "commitment".
+func describeCommitment(commitment planner.Commitment) string {
+	note := commitment.Note
+	if note == emptyLiteral {
+		note = gapsCommitmentLiteral
+	}
+	return fmt.Sprintf(gapsCommitmentFormat, note, planner.MinutesToMilitaryTime(commitment.StartClockMinutes), planner.MinutesToMilitaryTime(commitment.EndClockMinutes))
+}
@@ -0,0 +1,38 @@
+// cmd/schedule/gaps_test.go
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"SummerCamp25/planner"
+)
+
+func TestWriteGapReport(t *testing.T) {
+	art := testSession("Art", "2025-06-16", "09:00", "12:00")
+	result := &planner.Result{
+		ChildNames: []string{"Alice"},
+		Plans: map[string]*planner.Plan{
+			"Alice": {Child: planner.Child{Name: "Alice"}, Sessions: []planner.Session{art}},
+		},
+	}
+	var report strings.Builder
+	writeGapReport(&report, []planner.Session{art}, result)
+	want := "Alice gaps\n" +
+		"Week of 2025-06-16\n" +
+		"  Mon 2025-06-16 morning only, 6.0 hours open\n" +
+		"  Tue 2025-06-17 morning only, 6.0 hours open\n" +
+		"  Wed 2025-06-18 morning only, 6.0 hours open\n" +
+		"  Thu 2025-06-19 morning only, 6.0 hours open\n" +
+		"  Fri 2025-06-20 morning only, 6.0 hours open\n" +
+		"Alice uncovered 30.0 hours\n\n"
+	if report.String() != want {
+		t.Errorf("report =\n%s\nwant\n%s", report.String(), want)
+	}
+
+	var empty strings.Builder
+	writeGapReport(&empty, nil, result)
+	if empty.Len() != 0 {
+		t.Errorf("report without sessions = %q, want nothing", empty.String())
+	}
+}
@@ -0,0 +1,394 @@
+// cmd/schedule/ics.go
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"SummerCamp25/planner"
+)
+
+const (
+	icsBeginEventLiteral        = "BEGIN:VEVENT"
+	icsEndEventLiteral          = "END:VEVENT"
+	icsStartPropertyLiteral     = "DTSTART"
+	icsEndPropertyLiteral       = "DTEND"
+	icsRulePropertyLiteral      = "RRULE"
+	icsExceptionPropertyLiteral = "EXDATE"
+	icsDurationPropertyLiteral  = "DURATION"
+	icsCalendarZoneLiteral      = "X-WR-TIMEZONE"
+	icsBeginZoneLiteral         = "BEGIN:VTIMEZONE"
+	icsZoneIDPropertyLiteral    = "TZID"
+	icsDateTimeLayout           = "20060102T150405"
+	icsDateLayout               = "20060102"
+	icsUTCSuffix                = "Z"
+	icsTimeZoneParameter        = "TZID="
+	icsWeeklyFrequency          = "WEEKLY"
+	icsDailyFrequency           = "DAILY"
+	minutesPerDay               = 24 * 60
+	icsPathSeparator            = ","
+)
+
+var icsWeekdayAbbreviations = map[string]string{
+	"MO": "Mon", "TU": "Tue", "WE": "Wed", "TH": "Thu", "FR": "Fri", "SA": "Sat", "SU": "Sun",
+}
+
+// icsSupportedRuleParts are the RRULE parts ruleWindows understands; any
+// other part changes which days recur, so it is rejected rather than ignored.
+var icsSupportedRuleParts = map[string]struct{}{
+	"FREQ": {}, "BYDAY": {}, "UNTIL": {}, "COUNT": {}, "INTERVAL": {},
+}
+
+// icsDurationPattern matches the RFC 5545 durations events use, e.g. P1D or PT1H30M.
+var icsDurationPattern = regexp.MustCompile(`^\+?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// icsEvent holds the properties of one VEVENT that matter for busy time.
+type icsEvent struct {
+	start    time.Time
+	end      time.Time
+	duration time.Duration
+	allDay   bool
+	ruleText string
+	// zone reads UTC times: DTSTART's TZID, else the calendar's time zone;
+	// nil when neither is known.
+	zone *time.Location
+	// excludedDates are the EXDATE days, cancelled occurrences of the rule.
+	excludedDates []time.Time
+}
+
+// loadBusyCalendars reads a comma-separated list of .ics files, one calendar per driver.
+// A non-nil zone overrides each calendar's own time zone for UTC times.
+func loadBusyCalendars(pathList string, zone *time.Location) ([][]planner.WeeklyWindow, error) {
+	var calendars [][]planner.WeeklyWindow
+	for _, path := range strings.Split(pathList, icsPathSeparator) {
+		if path = strings.TrimSpace(path); path == emptyLiteral {
+			continue
+		}
+		busyWindows, loadError := loadICSFile(path, zone)
+		if loadError != nil {
+			return nil, loadError
+		}
+		calendars = append(calendars, busyWindows)
+	}
+	return calendars, nil
+}
+
+// loadICSFile turns each VEVENT of an iCalendar file into weekly busy windows.
+// Weekly and daily RRULEs with BYDAY, UNTIL and COUNT are honoured and EXDATE
+// days left out; any other rule is an error rather than a guess. UTC times are
+// read in zone, or without one in the calendar's X-WR-TIMEZONE or VTIMEZONE.
+func loadICSFile(icsPath string, zone *time.Location) ([]planner.WeeklyWindow, error) {
+	fileHandle, openError := os.Open(icsPath)
+	if openError != nil {
+		return nil, openError
+	}
+	defer fileHandle.Close()
+
+	var unfoldedLines []string
+	scanner := bufio.NewScanner(fileHandle)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(unfoldedLines) > 0 {
+			unfoldedLines[len(unfoldedLines)-1] += line[1:]
+			continue
+		}
+		unfoldedLines = append(unfoldedLines, line)
+	}
+	if scanError := scanner.Err(); scanError != nil {
+		return nil, scanError
+	}
+
+	if zone == nil {
+		zone = calendarZone(unfoldedLines)
+	}
+
+	var busyWindows []planner.WeeklyWindow
+	var current *icsEvent
+	for lineNumber, line := range unfoldedLines {
+		switch {
+		case line == icsBeginEventLiteral:
+			current = &icsEvent{zone: zone}
+		case line == icsEndEventLiteral:
+			if current == nil || current.start.IsZero() {
+				return nil, fmt.Errorf("%s line %d: event without %s", icsPath, lineNumber+1, icsStartPropertyLiteral)
+			}
+			if current.end.IsZero() && current.duration > 0 {
+				current.end = current.start.Add(current.duration)
+			}
+			eventWindows, eventError := current.busyWindows()
+			if eventError != nil {
+				return nil, fmt.Errorf("%s line %d: %w", icsPath, lineNumber+1, eventError)
+			}
+			busyWindows = append(busyWindows, eventWindows...)
+			current = nil
+		case current != nil:
+			name, parameters, value := splitICSProperty(line)
+			switch name {
+			case icsStartPropertyLiteral, icsEndPropertyLiteral:
+				timestamp, allDay, parseError := parseICSTime(parameters, value, current.zone)
+				if parseError != nil {
+					return nil, fmt.Errorf("%s line %d: %w", icsPath, lineNumber+1, parseError)
+				}
+				if name == icsStartPropertyLiteral {
+					current.start, current.allDay = timestamp, allDay
+					if startZone := icsParameterZone(parameters); startZone != nil {
+						current.zone = startZone
+					}
+				} else {
+					current.end = timestamp
+				}
+			case icsDurationPropertyLiteral:
+				duration, parseError := parseICSDuration(value)
+				if parseError != nil {
+					return nil, fmt.Errorf("%s line %d: %w", icsPath, lineNumber+1, parseError)
+				}
+				current.duration = duration
+			case icsRulePropertyLiteral:
+				current.ruleText = value
+			case icsExceptionPropertyLiteral:
+				for _, dateText := range strings.Split(value, ",") {
+					excluded, _, parseError := parseICSTime(parameters, strings.TrimSpace(dateText), current.zone)
+					if parseError != nil {
+						return nil, fmt.Errorf("%s line %d: %w", icsPath, lineNumber+1, parseError)
+					}
+					current.excludedDates = append(current.excludedDates, planner.CalendarDate(excluded))
+				}
+			}
+		}
+	}
+	return busyWindows, nil
+}
+
+func splitICSProperty(line string) (string, string, string) {
+	colon := strings.Index(line, ":")
+	if colon < 0 {
+		return line, emptyLiteral, emptyLiteral
+	}
+	head, value := line[:colon], line[colon+1:]
+	name, parameters, _ := strings.Cut(head, ";")
+	return strings.ToUpper(name), parameters, value
+}
+
+// calendarZone returns the zone named by the calendar's X-WR-TIMEZONE, or else
+// by its first VTIMEZONE, or nil when neither names a zone Go knows.
+func calendarZone(lines []string) *time.Location {
+	var zoneNames []string
+	inZone := false
+	for _, line := range lines {
+		name, _, value := splitICSProperty(line)
+		switch {
+		case name == icsCalendarZoneLiteral:
+			zoneNames = append([]string{value}, zoneNames...)
+		case line == icsBeginZoneLiteral:
+			inZone = true
+		case inZone && name == icsZoneIDPropertyLiteral:
+			zoneNames = append(zoneNames, value)
+			inZone = false
+		}
+	}
+	for _, zoneName := range zoneNames {
+		if zone, zoneError := time.LoadLocation(strings.TrimSpace(zoneName)); zoneError == nil {
+			return zone
+		}
+	}
+	return nil
+}
+
+// icsParameterZone returns the zone of a TZID property parameter, or nil
+// without one Go knows.
+func icsParameterZone(parameters string) *time.Location {
+	for _, parameter := range strings.Split(parameters, ";") {
+		if zoneName, hasZone := strings.CutPrefix(parameter, icsTimeZoneParameter); hasZone {
+			if zone, zoneError := time.LoadLocation(strings.Trim(zoneName, "\"")); zoneError == nil {
+				return zone
+			}
+		}
+	}
+	return nil
+}
+
+// parseICSDuration reads a DURATION value such as PT1H30M or P1D.
+func parseICSDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	parts := icsDurationPattern.FindStringSubmatch(value)
+	if parts == nil || strings.HasSuffix(value, "P") || strings.HasSuffix(value, "T") {
+		return 0, fmt.Errorf("invalid %s %q", icsDurationPropertyLiteral, value)
+	}
+	duration := time.Duration(0)
+	for partIndex, unit := range []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if parts[partIndex+1] != emptyLiteral {
+			count, _ := strconv.Atoi(parts[partIndex+1])
+			duration += time.Duration(count) * unit
+		}
+	}
+	return duration, nil
+}
+
+// parseICSTime reads DATE, floating, TZID and UTC forms. Wall-clock times are kept
+// as written since session times are local clock minutes too; UTC times are moved
+// to zone's wall clock first, so they need one.
+func parseICSTime(parameters, value string, zone *time.Location) (time.Time, bool, error) {
+	if len(value) == len(icsDateLayout) {
+		date, dateError := time.Parse(icsDateLayout, value)
+		return date, true, dateError
+	}
+	if strings.HasSuffix(value, icsUTCSuffix) {
+		if zone == nil {
+			return time.Time{}, false, fmt.Errorf("UTC time %s needs the calendar's time zone: add X-WR-TIMEZONE or pass -%s", value, flagICSZoneParameterNameLiteral)
+		}
+		timestamp, parseError := time.Parse(icsDateTimeLayout, strings.TrimSuffix(value, icsUTCSuffix))
+		if parseError != nil {
+			return time.Time{}, false, parseError
+		}
+		local := timestamp.In(zone)
+		return time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute(), 0, 0, time.UTC), false, nil
+	}
+	location := time.UTC
+	if parameterZone := icsParameterZone(parameters); parameterZone != nil {
+		location = parameterZone
+	}
+	timestamp, parseError := time.ParseInLocation(icsDateTimeLayout, value, location)
+	if parseError != nil {
+		return time.Time{}, false, parseError
+	}
+	return time.Date(timestamp.Year(), timestamp.Month(), timestamp.Day(), timestamp.Hour(), timestamp.Minute(), 0, 0, time.UTC), false, nil
+}
+
+// busyWindows expands the event into weekly windows on the day/clock-minute
+// model, split around its excluded dates.
+func (event icsEvent) busyWindows() ([]planner.WeeklyWindow, error) {
+	windows, ruleError := event.ruleWindows()
+	if ruleError != nil {
+		return nil, ruleError
+	}
+	for _, excluded := range event.excludedDates {
+		var kept []planner.WeeklyWindow
+		for _, window := range windows {
+			kept = append(kept, withoutDate(window, excluded)...)
+		}
+		windows = kept
+	}
+	return windows, nil
+}
+
+// withoutDate splits a window into the parts before and after date.
+func withoutDate(window planner.WeeklyWindow, date time.Time) []planner.WeeklyWindow {
+	if _, onWeekday := window.Weekdays[planner.WeekdayAbbreviation(date)]; !onWeekday || date.Before(window.FromDate) || date.After(window.ToDate) {
+		return []planner.WeeklyWindow{window}
+	}
+	var parts []planner.WeeklyWindow
+	if before := window; date.After(window.FromDate) {
+		before.ToDate = date.AddDate(0, 0, -1)
+		parts = append(parts, before)
+	}
+	if after := window; date.Before(window.ToDate) {
+		after.FromDate = date.AddDate(0, 0, 1)
+		parts = append(parts, after)
+	}
+	return parts
+}
+
+// ruleWindows expands the event and its RRULE, ignoring exceptions.
+func (event icsEvent) ruleWindows() ([]planner.WeeklyWindow, error) {
+	startClock := event.start.Hour()*60 + event.start.Minute()
+	endClock := minutesPerDay
+	if !event.allDay && !event.end.IsZero() && planner.CalendarDate(event.end).Equal(planner.CalendarDate(event.start)) {
+		endClock = event.end.Hour()*60 + event.end.Minute()
+	}
+	if event.allDay {
+		startClock = 0
+	}
+	firstDate := planner.CalendarDate(event.start)
+
+	if event.ruleText == emptyLiteral {
+		lastDate := firstDate
+		if !event.end.IsZero() && planner.CalendarDate(event.end).After(firstDate) {
+			lastDate = planner.CalendarDate(event.end)
+			if event.allDay || event.end.Hour()*60+event.end.Minute() == 0 {
+				lastDate = lastDate.AddDate(0, 0, -1)
+			}
+		}
+		allWeekdays := map[string]struct{}{}
+		for _, weekday := range planner.WeekdayAbbreviations {
+			allWeekdays[weekday] = struct{}{}
+		}
+		if lastDate.Equal(firstDate) {
+			allWeekdays = map[string]struct{}{planner.WeekdayAbbreviation(firstDate): {}}
+		} else {
+			startClock, endClock = 0, minutesPerDay
+		}
+		return []planner.WeeklyWindow{{Weekdays: allWeekdays, StartClockMinutes: startClock, EndClockMinutes: endClock, FromDate: firstDate, ToDate: lastDate}}, nil
+	}
+
+	ruleParts := map[string]string{}
+	for _, part := range strings.Split(event.ruleText, ";") {
+		if key, value, found := strings.Cut(part, "="); found {
+			key = strings.ToUpper(key)
+			if _, supported := icsSupportedRuleParts[key]; !supported {
+				return nil, fmt.Errorf("RRULE %s is not supported", part)
+			}
+			ruleParts[key] = value
+		}
+	}
+
+	weekdays := map[string]struct{}{}
+	if byDay := ruleParts["BYDAY"]; byDay != emptyLiteral {
+		for _, code := range strings.Split(byDay, ",") {
+			abbreviation, known := icsWeekdayAbbreviations[strings.ToUpper(strings.TrimSpace(code))]
+			if !known {
+				return nil, fmt.Errorf("RRULE BYDAY=%s is not supported", byDay)
+			}
+			weekdays[abbreviation] = struct{}{}
+		}
+	}
+
+	switch ruleParts["FREQ"] {
+	case icsWeeklyFrequency:
+		if len(weekdays) == 0 {
+			weekdays[planner.WeekdayAbbreviation(firstDate)] = struct{}{}
+		}
+	case icsDailyFrequency:
+		if len(weekdays) == 0 {
+			for _, weekday := range planner.WeekdayAbbreviations {
+				weekdays[weekday] = struct{}{}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("RRULE FREQ=%s is not supported", ruleParts["FREQ"])
+	}
+
+	if intervalText, present := ruleParts["INTERVAL"]; present && intervalText != "1" {
+		return nil, fmt.Errorf("RRULE INTERVAL=%s is not supported", intervalText)
+	}
+
+	lastDate := firstDate.AddDate(10, 0, 0)
+	if untilText, present := ruleParts["UNTIL"]; present {
+		until, _, untilError := parseICSTime(emptyLiteral, untilText, event.zone)
+		if untilError != nil {
+			return nil, fmt.Errorf("invalid UNTIL %q", untilText)
+		}
+		lastDate = planner.CalendarDate(until)
+	} else if countText, present := ruleParts["COUNT"]; present {
+		count, countError := strconv.Atoi(countText)
+		if countError != nil || count < 1 {
+			return nil, fmt.Errorf("invalid COUNT %q", countText)
+		}
+		occurrences := 0
+		for date := firstDate; ; date = date.AddDate(0, 0, 1) {
+			if _, onWeekday := weekdays[planner.WeekdayAbbreviation(date)]; onWeekday {
+				occurrences++
+				if occurrences == count {
+					lastDate = date
+					break
+				}
+			}
+		}
+	}
+
+	return []planner.WeeklyWindow{{Weekdays: weekdays, StartClockMinutes: startClock, EndClockMinutes: endClock, FromDate: firstDate, ToDate: lastDate}}, nil
+}
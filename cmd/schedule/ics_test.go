@@ -0,0 +1,187 @@
+// cmd/schedule/ics_test.go
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"SummerCamp25/planner"
+)
+
+// describeWindow renders a window as "Mon Wed 540-600 2025-06-16/2025-06-25".
+func describeWindow(window planner.WeeklyWindow) string {
+	var days []string
+	for _, day := range planner.WeekdayAbbreviations {
+		if _, present := window.Weekdays[day]; present {
+			days = append(days, day)
+		}
+	}
+	return fmt.Sprintf("%s %d-%d %s/%s", strings.Join(days, " "), window.StartClockMinutes, window.EndClockMinutes,
+		window.FromDate.Format(dateLayoutISOLiteral), window.ToDate.Format(dateLayoutISOLiteral))
+}
+
+func TestLoadICSFile(t *testing.T) {
+	toronto, _ := time.LoadLocation("America/Toronto")
+	tests := []struct {
+		name      string
+		header    string
+		event     string
+		zone      *time.Location
+		want      []string
+		wantError string
+	}{
+		{
+			name:  "a folded weekly rule with COUNT ends on its last occurrence",
+			event: "DTSTART:20250616T090000\r\nDTEND:20250616T100000\r\nRRULE:FREQ=WEEKLY;BYDAY=MO,W\r\n E;COUNT=4\r\n",
+			want:  []string{"Mon Wed 540-600 2025-06-16/2025-06-25"},
+		},
+		{
+			name:  "a daily rule runs until UNTIL",
+			event: "DTSTART:20250616T090000\nDTEND:20250616T100000\nRRULE:FREQ=DAILY;UNTIL=20250620\n",
+			want:  []string{"Mon Tue Wed Thu Fri Sat Sun 540-600 2025-06-16/2025-06-20"},
+		},
+		{
+			name:  "an EXDATE splits the rule around the cancelled day",
+			event: "DTSTART:20250616T090000\nDTEND:20250616T100000\nRRULE:FREQ=WEEKLY;BYDAY=MO;UNTIL=20250707\nEXDATE:20250623T090000\n",
+			want:  []string{"Mon 540-600 2025-06-16/2025-06-22", "Mon 540-600 2025-06-24/2025-07-07"},
+		},
+		{
+			name:  "a one-day all-day event blocks that whole day",
+			event: "DTSTART;VALUE=DATE:20250704\nDTEND;VALUE=DATE:20250705\n",
+			want:  []string{"Fri 0-1440 2025-07-04/2025-07-04"},
+		},
+		{
+			name:  "a multi-day all-day event blocks every day up to its exclusive end",
+			event: "DTSTART;VALUE=DATE:20250704\nDTEND;VALUE=DATE:20250707\n",
+			want:  []string{"Mon Tue Wed Thu Fri Sat Sun 0-1440 2025-07-04/2025-07-06"},
+		},
+		{
+			name:  "a DURATION sets the end",
+			event: "DTSTART:20250616T090000\nDURATION:PT1H30M\n",
+			want:  []string{"Mon 540-630 2025-06-16/2025-06-16"},
+		},
+		{
+			name:   "UTC times are read in the calendar's X-WR-TIMEZONE",
+			header: "X-WR-TIMEZONE:America/Toronto\n",
+			event:  "DTSTART:20250616T130000Z\nDTEND:20250616T140000Z\n",
+			want:   []string{"Mon 540-600 2025-06-16/2025-06-16"},
+		},
+		{
+			name:   "UTC times are read in the calendar's VTIMEZONE",
+			header: "BEGIN:VTIMEZONE\nTZID:Europe/London\nEND:VTIMEZONE\n",
+			event:  "DTSTART:20250616T080000Z\nDTEND:20250616T090000Z\n",
+			want:   []string{"Mon 540-600 2025-06-16/2025-06-16"},
+		},
+		{
+			name:   "an explicit zone wins over the calendar's",
+			header: "X-WR-TIMEZONE:Europe/London\n",
+			event:  "DTSTART:20250616T130000Z\nDTEND:20250616T140000Z\n",
+			zone:   toronto,
+			want:   []string{"Mon 540-600 2025-06-16/2025-06-16"},
+		},
+		{
+			name:  "a UTC UNTIL is read in the zone of DTSTART's TZID",
+			event: "DTSTART;TZID=America/Los_Angeles:20250616T143000\nDTEND;TZID=America/Los_Angeles:20250616T160000\nRRULE:FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20250829T000000Z\n",
+			want:  []string{"Mon Wed 870-960 2025-06-16/2025-08-28"},
+		},
+		{
+			name:      "UTC times without any zone are an error",
+			event:     "DTSTART:20250616T130000Z\nDTEND:20250616T140000Z\n",
+			wantError: "needs the calendar's time zone",
+		},
+		{
+			name:      "a monthly rule is an error",
+			event:     "DTSTART:20250616T090000\nRRULE:FREQ=MONTHLY\n",
+			wantError: "RRULE FREQ=MONTHLY is not supported",
+		},
+		{
+			name:      "BYMONTH is an error rather than ignored",
+			event:     "DTSTART:20250616T090000\nRRULE:FREQ=WEEKLY;BYMONTH=7\n",
+			wantError: "RRULE BYMONTH=7 is not supported",
+		},
+		{
+			name:      "WKST is an error rather than ignored",
+			event:     "DTSTART:20250616T090000\nRRULE:FREQ=WEEKLY;WKST=SU\n",
+			wantError: "RRULE WKST=SU is not supported",
+		},
+		{
+			name:      "a numbered BYDAY is an error rather than every Monday",
+			event:     "DTSTART:20250616T090000\nRRULE:FREQ=WEEKLY;BYDAY=1MO\n",
+			wantError: "RRULE BYDAY=1MO is not supported",
+		},
+		{
+			name:      "an interval other than 1 is an error",
+			event:     "DTSTART:20250616T090000\nRRULE:FREQ=WEEKLY;INTERVAL=2\n",
+			wantError: "RRULE INTERVAL=2 is not supported",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calendar := "BEGIN:VCALENDAR\n" + test.header + "BEGIN:VEVENT\n" + test.event + "END:VEVENT\nEND:VCALENDAR\n"
+			windows, loadError := loadICSFile(writeTestFile(t, "calendar.ics", calendar), test.zone)
+			if test.wantError != emptyLiteral {
+				if loadError == nil || !strings.Contains(loadError.Error(), test.wantError) {
+					t.Fatalf("error = %v, want one containing %q", loadError, test.wantError)
+				}
+				return
+			}
+			if loadError != nil {
+				t.Fatal(loadError)
+			}
+			var got []string
+			for _, window := range windows {
+				got = append(got, describeWindow(window))
+			}
+			if !slices.Equal(got, test.want) {
+				t.Errorf("windows = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestWithoutDate(t *testing.T) {
+	window, _ := planner.ParseWeeklyWindow("Mon,Wed", "09:00", "10:00", "2025-06-16", "2025-06-30")
+	tests := []struct {
+		date string
+		want []string
+	}{
+		{date: "2025-06-16", want: []string{"Mon Wed 540-600 2025-06-17/2025-06-30"}},
+		{date: "2025-06-18", want: []string{"Mon Wed 540-600 2025-06-16/2025-06-17", "Mon Wed 540-600 2025-06-19/2025-06-30"}},
+		{date: "2025-06-30", want: []string{"Mon Wed 540-600 2025-06-16/2025-06-29"}},
+		{date: "2025-06-17", want: []string{"Mon Wed 540-600 2025-06-16/2025-06-30"}},
+		{date: "2025-07-02", want: []string{"Mon Wed 540-600 2025-06-16/2025-06-30"}},
+	}
+	for _, test := range tests {
+		date, _ := time.Parse(dateLayoutISOLiteral, test.date)
+		var got []string
+		for _, part := range withoutDate(window, date) {
+			got = append(got, describeWindow(part))
+		}
+		if !slices.Equal(got, test.want) {
+			t.Errorf("without %s = %q, want %q", test.date, got, test.want)
+		}
+	}
+}
+
+func TestParseICSDuration(t *testing.T) {
+	for value, want := range map[string]time.Duration{
+		"PT1H30M": 90 * time.Minute,
+		"P1D":     24 * time.Hour,
+		"P1W":     7 * 24 * time.Hour,
+		"P1DT2H":  26 * time.Hour,
+		"PT45S":   45 * time.Second,
+	} {
+		if got, parseError := parseICSDuration(value); parseError != nil || got != want {
+			t.Errorf("parseICSDuration(%q) = %v, %v; want %v", value, got, parseError, want)
+		}
+	}
+	for _, value := range []string{"P", "PT", "-PT1H", "1H", "P1DT"} {
+		if _, parseError := parseICSDuration(value); parseError == nil {
+			t.Errorf("parseICSDuration(%q) parsed", value)
+		}
+	}
+}
@@ -0,0 +1,389 @@
+// cmd/schedule/lint.go
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"SummerCamp25/planner"
+)
+
+const (
+	lintCommandLiteral             = "lint"
+	fatalLintFlagsLiteral          = "FATAL: lint needs -sessions and -want"
+	lintCleanLiteral               = "lint: no problems found"
+	lintUnmatchedHeadingLiteral    = "Want titles matching no session"
+	lintUnmentionedHeadingLiteral  = "Sessions never mentioned in the want file"
+	lintAgelessHeadingLiteral      = "Children without a parseable age"
+	lintDuplicateHeadingLiteral    = "Duplicate want rows"
+	lintPriorityHeadingLiteral     = "Unknown priority values"
+	lintIneligibleHeadingLiteral   = "Sessions no child is old or young enough for"
+	lintClosestMatchFormatLiteral  = "  %s: %q (closest session: %q)\n"
+	lintNoCandidatesFormatLiteral  = "  %s: %q\n"
+	lintDuplicateFormatLiteral     = "  %s repeats %s: %q\n"
+	lintPriorityFormatLiteral      = "  %s: %q\n"
+	lintIneligibleFormatLiteral    = "  %q (ages %s)\n"
+	lintFindingsSummaryFormat      = "lint: %d problem(s)\n"
+	lintAgeBoundUnknownLiteral     = "?"
+	lintAgeRangeSeparatorLiteral   = "–"
+	lintPlainLineFormatLiteral     = "  %s\n"
+	lintQuotedLineFormatLiteral    = "  %q\n"
+	lintStructuredLocationFormat   = "children[%d].preferences[%q]"
+	lintCSVRowLocationFormat       = "row %d"
+	lintCSVCellLocationFormat      = "row %d column %d (%s)"
+	lintStructuredChildAgeLocation = "children[%d] %s"
+)
+
+// lintWantTitle is one activity title as written in the want file.
+type lintWantTitle struct {
+	title    string
+	location string
+}
+
+// lintPriorityCell is one raw priority value and where it was found.
+type lintPriorityCell struct {
+	value    string
+	location string
+}
+
+// lintSection is one heading of the lint report and its lines.
+type lintSection struct {
+	heading string
+	lines   []string
+}
+
+// lintWantContents is the want file read without any validation.
+type lintWantContents struct {
+	titles          []lintWantTitle
+	priorityCells   []lintPriorityCell
+	agelessChildren []string
+	duplicateRows   []string
+}
+
+// runLint cross-checks a want file against sessions.json and prints every inconsistency.
+func runLint(arguments []string) {
+	flags := flag.NewFlagSet(lintCommandLiteral, flag.ExitOnError)
+	sessionsPathFlag := flags.String(flagSessionsParameterNameLiteral, emptyLiteral, emptyLiteral)
+	wantPathFlag := flags.String(flagWantParameterNameLiteral, emptyLiteral, emptyLiteral)
+	scalePathFlag := flags.String(flagScaleParameterNameLiteral, emptyLiteral, flagScaleParameterUsageLiteral)
+	aliasesPathFlag := flags.String(flagAliasesParameterNameLiteral, emptyLiteral, flagAliasesParameterUsageLiteral)
+	_ = flags.Parse(arguments)
+
+	if *sessionsPathFlag == emptyLiteral || *wantPathFlag == emptyLiteral {
+		fmt.Println(fatalLintFlagsLiteral)
+		return
+	}
+
+	scale := planner.DefaultPriorityScale()
+	if *scalePathFlag != emptyLiteral {
+		loadedScale, scaleError := loadPriorityScale(*scalePathFlag)
+		if scaleError != nil {
+			fmt.Println("FATAL:", scaleError)
+			return
+		}
+		scale = loadedScale
+	}
+
+	aliases := map[string]string{}
+	if *aliasesPathFlag != emptyLiteral {
+		loadedAliases, aliasError := loadAliasFile(*aliasesPathFlag)
+		if aliasError != nil {
+			fmt.Println("FATAL:", aliasError)
+			return
+		}
+		aliases = loadedAliases
+	}
+
+	contents, readError := readWantForLint(*wantPathFlag)
+	if readError != nil {
+		fmt.Println("FATAL:", readError)
+		return
+	}
+	sessions, sessionsError := loadSessions(*sessionsPathFlag)
+	if sessionsError != nil {
+		fmt.Println("FATAL:", sessionsError)
+		return
+	}
+	// A want file that still fails to load only skips the age check.
+	wantData, _ := loadWantFile(*wantPathFlag, permissiveScale(scale, contents.priorityCells))
+
+	findings := 0
+	for _, section := range lintFindings(contents, sessions, aliases, scale, wantData) {
+		fmt.Println(section.heading)
+		for _, line := range section.lines {
+			fmt.Print(line)
+		}
+		fmt.Println()
+		findings += len(section.lines)
+	}
+
+	if findings == 0 {
+		fmt.Println(lintCleanLiteral)
+		return
+	}
+	fmt.Printf(lintFindingsSummaryFormat, findings)
+	os.Exit(1)
+}
+
+// lintFindings cross-checks the want file's contents against the sessions, matching titles through aliases,
+// and returns the sections that found something, in report order.
+func lintFindings(contents lintWantContents, sessions []planner.Session, aliases map[string]string, scale planner.PriorityScale, wantData planner.Preferences) []lintSection {
+	var sections []lintSection
+	addSection := func(heading string, lines []string) {
+		if len(lines) > 0 {
+			sections = append(sections, lintSection{heading: heading, lines: lines})
+		}
+	}
+
+	sessionTitleList := distinctSessionTitles(sessions)
+	var wantTitleList []string
+	for _, wanted := range contents.titles {
+		wantTitleList = append(wantTitleList, wanted.title)
+	}
+	matches, unmatchedTitles := matchWantTitles(wantTitleList, sessionTitleList, aliases)
+	unmatchedSet := map[string]struct{}{}
+	for _, title := range unmatchedTitles {
+		unmatchedSet[title] = struct{}{}
+	}
+	matchedSessionTitles := map[string]struct{}{}
+	for _, match := range matches {
+		matchedSessionTitles[match.sessionTitle] = struct{}{}
+	}
+
+	var unmatchedLines []string
+	for _, wanted := range contents.titles {
+		if _, unmatched := unmatchedSet[wanted.title]; !unmatched {
+			continue
+		}
+		if closest := closestTitle(wanted.title, sessionTitleList); closest != emptyLiteral {
+			unmatchedLines = append(unmatchedLines, fmt.Sprintf(lintClosestMatchFormatLiteral, wanted.location, wanted.title, closest))
+		} else {
+			unmatchedLines = append(unmatchedLines, fmt.Sprintf(lintNoCandidatesFormatLiteral, wanted.location, wanted.title))
+		}
+	}
+	addSection(lintUnmatchedHeadingLiteral, unmatchedLines)
+
+	var unmentionedLines []string
+	for _, title := range sessionTitleList {
+		if _, mentioned := matchedSessionTitles[title]; !mentioned {
+			unmentionedLines = append(unmentionedLines, fmt.Sprintf(lintQuotedLineFormatLiteral, title))
+		}
+	}
+	addSection(lintUnmentionedHeadingLiteral, unmentionedLines)
+
+	var agelessLines []string
+	for _, childName := range contents.agelessChildren {
+		agelessLines = append(agelessLines, fmt.Sprintf(lintPlainLineFormatLiteral, childName))
+	}
+	addSection(lintAgelessHeadingLiteral, agelessLines)
+
+	addSection(lintDuplicateHeadingLiteral, contents.duplicateRows)
+
+	var priorityLines []string
+	for _, cell := range contents.priorityCells {
+		if _, _, known := scale.Lookup(cell.value); !known {
+			priorityLines = append(priorityLines, fmt.Sprintf(lintPriorityFormatLiteral, cell.location, cell.value))
+		}
+	}
+	addSection(lintPriorityHeadingLiteral, priorityLines)
+
+	var ineligibleLines []string
+	if len(wantData.Children) > 0 {
+		reported := map[string]struct{}{}
+		for _, session := range sessions {
+			anyChildFits := false
+			for _, child := range wantData.Children {
+				if session.AdmitsAge(child.AgeOn(session.StartDate())) {
+					anyChildFits = true
+					break
+				}
+			}
+			if _, alreadyReported := reported[session.ActivityName]; anyChildFits || alreadyReported {
+				continue
+			}
+			reported[session.ActivityName] = struct{}{}
+			ineligibleLines = append(ineligibleLines, fmt.Sprintf(lintIneligibleFormatLiteral, session.ActivityName, formatAgeRange(session)))
+		}
+	}
+	addSection(lintIneligibleHeadingLiteral, ineligibleLines)
+	return sections
+}
+
+// readWantForLint reads want.csv or the structured want file without rejecting anything.
+func readWantForLint(wantPath string) (lintWantContents, error) {
+	if strings.EqualFold(filepath.Ext(wantPath), structuredWantExtensionLiteral) {
+		return readStructuredWantForLint(wantPath)
+	}
+
+	fileHandle, openError := os.Open(wantPath)
+	if openError != nil {
+		return lintWantContents{}, openError
+	}
+	defer fileHandle.Close()
+
+	csvReader := csv.NewReader(fileHandle)
+	csvReader.FieldsPerRecord = -1
+	rows, readError := csvReader.ReadAll()
+	if readError != nil {
+		return lintWantContents{}, readError
+	}
+	if len(rows) == 0 {
+		return lintWantContents{}, fmt.Errorf("%s: empty file", wantPath)
+	}
+	headerRow := rows[0]
+
+	var contents lintWantContents
+	ageColumnByChild := map[string]int{}
+	var priorityColumns []int
+	for columnIndex, headerValue := range headerRow {
+		headerLower := strings.ToLower(headerValue)
+		childName := strings.Trim(strings.Split(headerValue, "'")[0], "\" ")
+		switch {
+		case strings.Contains(headerLower, "'s age"):
+			ageColumnByChild[childName] = columnIndex
+		case strings.Contains(headerLower, "'s priority"):
+			priorityColumns = append(priorityColumns, columnIndex)
+			if _, hasAgeColumn := ageColumnByChild[childName]; !hasAgeColumn {
+				ageColumnByChild[childName] = -1
+			}
+		}
+	}
+
+	firstRowByTitle := map[string]int{}
+	childHasAge := map[string]bool{}
+	for rowIndex, row := range rows[1:] {
+		rowNumber := rowIndex + 2
+		if len(row) == 0 {
+			continue
+		}
+		title := strings.TrimSpace(row[0])
+		contents.titles = append(contents.titles, lintWantTitle{title: title, location: fmt.Sprintf(lintCSVRowLocationFormat, rowNumber)})
+		if firstRow, duplicate := firstRowByTitle[title]; duplicate {
+			contents.duplicateRows = append(contents.duplicateRows, fmt.Sprintf(lintDuplicateFormatLiteral, fmt.Sprintf(lintCSVRowLocationFormat, rowNumber), fmt.Sprintf(lintCSVRowLocationFormat, firstRow), title))
+		} else {
+			firstRowByTitle[title] = rowNumber
+		}
+		for childName, ageColumn := range ageColumnByChild {
+			if ageColumn >= 0 && ageColumn < len(row) {
+				if _, parseError := strconv.Atoi(strings.TrimSpace(row[ageColumn])); parseError == nil {
+					childHasAge[childName] = true
+				}
+			}
+		}
+		for _, priorityColumn := range priorityColumns {
+			if priorityColumn < len(row) {
+				contents.priorityCells = append(contents.priorityCells, lintPriorityCell{
+					value:    strings.TrimSpace(row[priorityColumn]),
+					location: fmt.Sprintf(lintCSVCellLocationFormat, rowNumber, priorityColumn+1, headerRow[priorityColumn]),
+				})
+			}
+		}
+	}
+	for childName := range ageColumnByChild {
+		if !childHasAge[childName] {
+			contents.agelessChildren = append(contents.agelessChildren, childName)
+		}
+	}
+	sort.Strings(contents.agelessChildren)
+	return contents, nil
+}
+
+func readStructuredWantForLint(wantJSONPath string) (lintWantContents, error) {
+	jsonBytes, readError := os.ReadFile(wantJSONPath)
+	if readError != nil {
+		return lintWantContents{}, readError
+	}
+	var structured structuredWantFile
+	if decodeError := json.Unmarshal(jsonBytes, &structured); decodeError != nil {
+		return lintWantContents{}, fmt.Errorf("%s: %w", wantJSONPath, decodeError)
+	}
+
+	var contents lintWantContents
+	seenTitles := map[string]struct{}{}
+	for childIndex, child := range structured.Children {
+		if child.Age <= 0 {
+			if _, birthdateError := time.Parse(dateLayoutISOLiteral, child.Birthdate); birthdateError != nil {
+				contents.agelessChildren = append(contents.agelessChildren, fmt.Sprintf(lintStructuredChildAgeLocation, childIndex, child.Name))
+			}
+		}
+		var titles []string
+		for title := range child.Preferences {
+			titles = append(titles, title)
+		}
+		sort.Strings(titles)
+		for _, title := range titles {
+			location := fmt.Sprintf(lintStructuredLocationFormat, childIndex, title)
+			contents.priorityCells = append(contents.priorityCells, lintPriorityCell{value: child.Preferences[title], location: location})
+			if _, seen := seenTitles[title]; !seen {
+				seenTitles[title] = struct{}{}
+				contents.titles = append(contents.titles, lintWantTitle{title: title, location: location})
+			}
+		}
+	}
+	return contents, nil
+}
+
+// permissiveScale extends scale with every unknown value so the want file can still load for age checks.
+func permissiveScale(scale planner.PriorityScale, cells []lintPriorityCell) planner.PriorityScale {
+	extended := scale
+	for _, cell := range cells {
+		if _, _, known := scale.Lookup(cell.value); !known {
+			extended = extended.WithWord(cell.value, 0)
+		}
+	}
+	return extended
+}
+
+// closestTitle returns the candidate with the smallest edit distance to title,
+// or "" when nothing is within half the title's length.
+func closestTitle(title string, candidates []string) string {
+	normalizedTitle := strings.ToLower(title)
+	best, bestDistance := emptyLiteral, len(normalizedTitle)/2+1
+	for _, candidate := range candidates {
+		if distance := editDistance(normalizedTitle, strings.ToLower(candidate)); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between two strings, by rune.
+func editDistance(first, second string) int {
+	firstRunes, secondRunes := []rune(first), []rune(second)
+	previous := make([]int, len(secondRunes)+1)
+	current := make([]int, len(secondRunes)+1)
+	for index := range previous {
+		previous[index] = index
+	}
+	for firstIndex := 1; firstIndex <= len(firstRunes); firstIndex++ {
+		current[0] = firstIndex
+		for secondIndex := 1; secondIndex <= len(secondRunes); secondIndex++ {
+			substitution := previous[secondIndex-1]
+			if firstRunes[firstIndex-1] != secondRunes[secondIndex-1] {
+				substitution++
+			}
+			current[secondIndex] = min(previous[secondIndex]+1, current[secondIndex-1]+1, substitution)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(secondRunes)]
+}
+
+func formatAgeRange(session planner.Session) string {
+	lower, upper := lintAgeBoundUnknownLiteral, lintAgeBoundUnknownLiteral
+	if session.MinimumAgeInclusive != nil {
+		lower = strconv.Itoa(*session.MinimumAgeInclusive)
+	}
+	if session.MaximumAgeExclusive != nil {
+		upper = strconv.Itoa(*session.MaximumAgeExclusive)
+	}
+	return lower + lintAgeRangeSeparatorLiteral + upper
+}
@@ -0,0 +1,91 @@
+// cmd/schedule/lint_test.go
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"SummerCamp25/planner"
+)
+
+// withAges returns the session open to ages minimum up to but excluding maximum.
+func withAges(session planner.Session, minimum, maximum int) planner.Session {
+	session.MinimumAgeInclusive, session.MaximumAgeExclusive = &minimum, &maximum
+	return session
+}
+
+func TestLintFindings(t *testing.T) {
+	const week = "2025-06-16"
+	wantCSV := "Activity,Alice's Age,Alice's Priority,Bob's Priority\n" +
+		"Art,8,High,Low\n" +
+		"Swimmin,8,Hihg,\n" +
+		"Art,8,Low,Low\n" +
+		"Zzzz,8,,High\n"
+	wantPath := writeTestFile(t, "want.csv", wantCSV)
+	contents, readError := readWantForLint(wantPath)
+	if readError != nil {
+		t.Fatal(readError)
+	}
+	scale := planner.DefaultPriorityScale()
+	wantData, wantError := loadWantFile(wantPath, permissiveScale(scale, contents.priorityCells))
+	if wantError != nil {
+		t.Fatal(wantError)
+	}
+	sessions := []planner.Session{
+		testSession("Art", week, "09:00", "12:00"),
+		withAges(testSession("Chess", week, "09:00", "12:00"), 5, 10),
+		withAges(testSession("Swimming", week, "09:00", "12:00"), 14, 18),
+	}
+
+	want := []lintSection{
+		{heading: lintUnmatchedHeadingLiteral, lines: []string{
+			"  row 3: \"Swimmin\" (closest session: \"Swimming\")\n",
+			"  row 5: \"Zzzz\"\n",
+		}},
+		{heading: lintUnmentionedHeadingLiteral, lines: []string{"  \"Chess\"\n", "  \"Swimming\"\n"}},
+		{heading: lintAgelessHeadingLiteral, lines: []string{"  Bob\n"}},
+		{heading: lintDuplicateHeadingLiteral, lines: []string{"  row 4 repeats row 2: \"Art\"\n"}},
+		{heading: lintPriorityHeadingLiteral, lines: []string{"  row 3 column 3 (Alice's Priority): \"Hihg\"\n"}},
+		{heading: lintIneligibleHeadingLiteral, lines: []string{"  \"Swimming\" (ages 14–18)\n"}},
+	}
+	if sections := lintFindings(contents, sessions, nil, scale, wantData); !reflect.DeepEqual(sections, want) {
+		t.Errorf("lintFindings() =\n%q\nwant\n%q", sections, want)
+	}
+}
+
+func TestLintFindingsClean(t *testing.T) {
+	wantPath := writeTestFile(t, "want.json", `{"children": [{"name": "Alice", "age": 8, "preferences": {"Art": "High"}}]}`)
+	contents, readError := readWantForLint(wantPath)
+	if readError != nil {
+		t.Fatal(readError)
+	}
+	wantData, wantError := loadWantFile(wantPath, planner.DefaultPriorityScale())
+	if wantError != nil {
+		t.Fatal(wantError)
+	}
+	sessions := []planner.Session{withAges(testSession("Art", "2025-06-16", "09:00", "12:00"), 6, 10)}
+	if sections := lintFindings(contents, sessions, nil, planner.DefaultPriorityScale(), wantData); len(sections) != 0 {
+		t.Errorf("lintFindings() = %q, want no problems", sections)
+	}
+}
+
+func TestClosestTitle(t *testing.T) {
+	candidates := []string{"Art Studio", "Chess Club", "Swimming"}
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{title: "art studio", want: "Art Studio"},
+		{title: "Chess Clubb", want: "Chess Club"},
+		{title: "Swiming", want: "Swimming"},
+		{title: "Robotics", want: emptyLiteral},
+	}
+	for _, test := range tests {
+		if closest := closestTitle(test.title, candidates); closest != test.want {
+			t.Errorf("closestTitle(%q) = %q, want %q", test.title, closest, test.want)
+		}
+	}
+	if distance := editDistance("kitten", "sitting"); distance != 3 {
+		t.Errorf("editDistance(kitten, sitting) = %d, want 3", distance)
+	}
+}
@@ -1,117 +1,453 @@
 // cmd/schedule/main.go
 // Command schedule builds an optimized camp schedule for multiple children.
+// The planning itself lives in package planner; this command reads the
+// want, session and constraint files and prints or serves the result.
 package main
 
 import (
+	"context"
 	"encoding/csv"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
+
+	"SummerCamp25/planner"
 )
 
 const (
 	emptyLiteral                           = ""
-	availabilityAvailableLiteral           = "available"
-	availabilityStartingSoonLiteral        = "starting soon"
-	availabilitySpaceLeftIdentifierLiteral = "space"
-	availabilityLeftIdentifierLiteral      = "left"
-	bufferMinutesBetweenSessions           = 120
 	jointScheduleHeadingLiteral            = "Joint schedule"
 	childScheduleHeadingSuffixLiteral      = "schedule"
 	flagSessionsParameterNameLiteral       = "sessions"
 	flagWantParameterNameLiteral           = "want"
 	flagJSONParameterNameLiteral           = "json"
+	flagCoverageParameterNameLiteral       = "coverage"
+	flagCoverageParameterUsageLiteral      = "path to coverage CSV (Child,Days,Start,End,From,To); switches to coverage-first planning"
+	flagCommitmentsParameterNameLiteral    = "commitments"
+	flagCommitmentsParameterUsageLiteral   = "path to commitments CSV (Child,Days,Start,End,From,To,Note) of recurring non-camp blocks"
+	flagBufferParameterNameLiteral         = "buffer"
+	flagBufferParameterUsageLiteral        = "minutes required between two sessions of the same child"
+	coverageSummaryFormatLiteral           = "%s coverage %.1f of %.1f required hours\n"
+	commitmentSummaryFormatLiteral         = "%s commitments cover %.1f more required hours (not camp)\n"
+	flagBlackoutsParameterNameLiteral      = "blackouts"
+	flagBlackoutsParameterUsageLiteral     = "path to blackout CSV (Child,From,To,Note); * or empty Child means the whole family"
+	flagBlackoutToleranceNameLiteral       = "blackout-tolerance"
+	flagBlackoutToleranceUsageLiteral      = "meeting days a session may lose to a blackout before it is excluded"
+	missedDatesPrefixLiteral               = "misses"
+	missedDatesSeparatorLiteral            = ","
+	flagTogetherBonusParameterNameLiteral  = "together-bonus"
+	flagTogetherBonusParameterUsageLiteral = "score added for every extra sibling attending the same session"
+	withSiblingsPrefixLiteral              = "with"
+	siblingSeparatorLiteral                = ", "
+	flagTravelParameterNameLiteral         = "travel"
+	flagTravelParameterUsageLiteral        = "path to travel CSV (Location,Minutes) of one-way minutes from home"
+	flagDriversParameterNameLiteral        = "drivers"
+	flagDriversParameterUsageLiteral       = "drivers available per weekday, e.g. Mon-Thu:2,Fri:1; unlisted days are not checked"
+	rejectedHeadingLiteral                 = "Rejected"
+	flagICSParameterNameLiteral            = "ics"
+	flagICSParameterUsageLiteral           = "comma-separated .ics files of parent commitments, one calendar per driver"
+	flagICSZoneParameterNameLiteral        = "ics-zone"
+	flagICSZoneParameterUsageLiteral       = "time zone for UTC times in -ics calendars, e.g. America/Toronto; defaults to each calendar's own"
+	flagScaleParameterNameLiteral          = "scale"
+	flagScaleParameterUsageLiteral         = "path to priority scale CSV (Word,Score); Score \"must\" marks a hard requirement"
+	flagAliasesParameterNameLiteral        = "aliases"
+	flagAliasesParameterUsageLiteral       = "path to alias CSV (Want,Title) mapping want rows to scraped titles"
+	flagMatchReportParameterNameLiteral    = "match-report"
+	flagMatchReportParameterUsageLiteral   = "print which want rows matched which scraped titles, and how"
+	flagBackupsParameterNameLiteral        = "backups"
+	flagBackupsParameterUsageLiteral       = "fallback sessions to list for each chosen session; 0 disables them"
+	backupPrefixLiteral                    = "  backup"
 	fatalMissingFlagsLiteral               = "FATAL: -sessions and -want are required"
 	outputWrittenPrefixLiteral             = "wrote"
 	dateLayoutISOLiteral                   = "2006-01-02"
-	priorityHighLiteral                    = "High"
-	priorityMediumLiteral                  = "Medium"
-	priorityLowLiteral                     = "Low"
-	priorityNoLiteral                      = "No"
 )
 
-var priorityScoreByWord = map[string]int{
-	priorityHighLiteral:   3,
-	priorityMediumLiteral: 2,
-	priorityLowLiteral:    1,
-	priorityNoLiteral:     0,
-}
-
-type Session struct {
-	ActivityName         string            `json:"title"`
-	StartDateUnixSeconds int64             `json:"startDateUnix"`
-	EndDateUnixSeconds   int64             `json:"endDateUnix"`
-	DaysOfWeek           []string          `json:"days"`
-	StartTimeMilitary    string            `json:"startTime"`
-	EndTimeMilitary      string            `json:"endTime"`
-	MinimumAgeInclusive  *int              `json:"minAge"`
-	MaximumAgeExclusive  *int              `json:"maxAge"`
-	AvailabilityText     string            `json:"availability"`
-	PageURL              string            `json:"pageUrl"`
-	InterestedPriorities map[string]string `json:"interested"`
-	startDate            time.Time
-	endDate              time.Time
-	startClockMinutes    int
-	endClockMinutes      int
-}
-
-type wantFileData struct {
-	childAgesByName        map[string]int
-	childNamesSorted       []string
-	sessionPriorityByChild map[string]map[string]string
-}
-
-type childPlan struct {
-	scheduledSessions     []Session
-	enrolledActivitiesSet map[string]struct{}
-}
-
 type simpleSessionJSON struct {
-	Activity  string `json:"activity"`
-	StartDate string `json:"startDate"`
-	EndDate   string `json:"endDate"`
-	URL       string `json:"url"`
+	Activity     string            `json:"activity"`
+	StartDate    string            `json:"startDate"`
+	EndDate      string            `json:"endDate"`
+	URL          string            `json:"url"`
+	Days         []string          `json:"days,omitempty"`
+	StartTime    string            `json:"startTime,omitempty"`
+	EndTime      string            `json:"endTime,omitempty"`
+	Location     string            `json:"location,omitempty"`
+	Price        string            `json:"price,omitempty"`
+	Number       string            `json:"activityNumber,omitempty"`
+	Registration string            `json:"registrationOpens,omitempty"`
+	Availability string            `json:"availability,omitempty"`
+	Categories   []string          `json:"categories,omitempty"`
+	Status       string            `json:"status,omitempty"`
+	SpotsLeft    *int              `json:"spotsLeft,omitempty"`
+	Overbooked   bool              `json:"overbooked,omitempty"`
+	MinimumAge   *int              `json:"minAge,omitempty"`
+	MaximumAge   *int              `json:"maxAge,omitempty"`
+	Priorities   map[string]string `json:"priorities,omitempty"`
+	Score        int               `json:"score,omitempty"`
+	// PriorityLevel is the scale's display level of Score: must, high, medium or low.
+	PriorityLevel string   `json:"priorityLevel,omitempty"`
+	MissedDates   []string `json:"missedDates,omitempty"`
+	With          []string `json:"with,omitempty"`
+	// Friends are the friends from other families sharing the session, as "Zoe (Jones)".
+	Friends []string `json:"friends,omitempty"`
+	// Probability is the chance registering succeeds, set only when planning for expected score.
+	Probability float64 `json:"probability,omitempty"`
+	// Backups are the ranked fallbacks for a chosen session.
+	Backups []simpleSessionJSON `json:"backups,omitempty"`
 }
 
+// exportJSON is the -json output. Candidates lists, per child, the eligible
+// sessions the planner did not choose; Commitments their recurring non-camp
+// blocks, with the note as activity; Satisfaction their share of wishes
+// fulfilled. Shared, only written by families, lists each child's sessions
+// with friends from other families.
 type exportJSON struct {
-	Joint    []simpleSessionJSON            `json:"joint"`
-	Children map[string][]simpleSessionJSON `json:"children"`
+	Joint        []simpleSessionJSON            `json:"joint"`
+	Children     map[string][]simpleSessionJSON `json:"children"`
+	Candidates   map[string][]simpleSessionJSON `json:"candidates,omitempty"`
+	Commitments  map[string][]simpleSessionJSON `json:"commitments,omitempty"`
+	Satisfaction map[string]satisfactionJSON    `json:"satisfaction,omitempty"`
+	Shared       []simpleSessionJSON            `json:"shared,omitempty"`
+	Rejections   []string                       `json:"rejections,omitempty"`
 }
 
 // main entry
 func main() {
-	sessionsPathFlag := flag.String(flagSessionsParameterNameLiteral, emptyLiteral, emptyLiteral)
-	wantPathFlag := flag.String(flagWantParameterNameLiteral, emptyLiteral, emptyLiteral)
+	if len(os.Args) > 1 && os.Args[1] == convertCommandLiteral {
+		runConvert(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == lintCommandLiteral {
+		runLint(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == timelineCommandLiteral {
+		runTimeline(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == serveCommandLiteral {
+		runServe(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == apiCommandLiteral {
+		runAPI(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == familiesCommandLiteral {
+		runFamilies(os.Args[2:])
+		return
+	}
+
+	values := registerPlannerFlags(flag.CommandLine)
 	jsonOutputPathFlag := flag.String(flagJSONParameterNameLiteral, emptyLiteral, emptyLiteral)
+	matchReportFlag := flag.Bool(flagMatchReportParameterNameLiteral, false, flagMatchReportParameterUsageLiteral)
+	checklistPathFlag := flag.String(flagChecklistParameterNameLiteral, emptyLiteral, flagChecklistParameterUsageLiteral)
 	flag.Parse()
 
-	if *sessionsPathFlag == emptyLiteral || *wantPathFlag == emptyLiteral {
+	if *values.sessionsPath == emptyLiteral || *values.wantPath == emptyLiteral {
 		fmt.Println(fatalMissingFlagsLiteral)
 		return
 	}
 
-	wantData := loadWantFile(*wantPathFlag)
-	rawSessions := transformRawSessions(*sessionsPathFlag, wantData)
-	optimizedPlans, jointSessions := buildOptimizedPlans(rawSessions, wantData)
+	scale, scaleError := values.loadScale()
+	if scaleError != nil {
+		fmt.Println("FATAL:", scaleError)
+		return
+	}
+	wantData, wantError := loadWantFile(*values.wantPath, scale)
+	if wantError != nil {
+		fmt.Println("FATAL:", wantError)
+		return
+	}
+	aliases, aliasError := values.loadAliases()
+	if aliasError != nil {
+		fmt.Println("FATAL:", aliasError)
+		return
+	}
+	categories, categoriesError := values.loadCategories()
+	if categoriesError != nil {
+		fmt.Println("FATAL:", categoriesError)
+		return
+	}
+	rawSessions, sessionsError := loadSessions(*values.sessionsPath)
+	if sessionsError != nil {
+		fmt.Println("FATAL:", sessionsError)
+		return
+	}
+	categories.Tag(rawSessions)
+	titleMatches, unmatchedWantTitles := matchWantTitles(wantData.Titles(), distinctSessionTitles(rawSessions), aliases)
+	applyTitleMatches(rawSessions, titleMatches)
+	if *matchReportFlag {
+		printMatchReport(titleMatches, unmatchedWantTitles)
+	}
+
+	options, preferences, optionsError := values.buildOptions(wantData)
+	if optionsError != nil {
+		fmt.Println("FATAL:", optionsError)
+		return
+	}
 
+	result, planError := planner.New(options).Plan(context.Background(), rawSessions, preferences)
+	if planError != nil {
+		fmt.Println("FATAL:", planError)
+		return
+	}
+	if requirementError := result.RequirementsError(); requirementError != nil {
+		fmt.Println("FATAL:", requirementError)
+		return
+	}
+
+	if *checklistPathFlag != emptyLiteral {
+		if writeError := writeChecklistFile(*checklistPathFlag, result, preferences); writeError != nil {
+			fmt.Println("FATAL:", writeError)
+			return
+		}
+	}
 	if *jsonOutputPathFlag != emptyLiteral {
-		writeJSONOutput(*jsonOutputPathFlag, optimizedPlans, jointSessions, wantData.childNamesSorted)
+		if writeError := writeJSONOutput(*jsonOutputPathFlag, buildExport(rawSessions, result, preferences)); writeError != nil {
+			fmt.Println("FATAL:", writeError)
+		}
 		return
 	}
 
-	printTextOutput(jointSessions, optimizedPlans, wantData.childNamesSorted)
+	writeTextOutput(os.Stdout, result)
+	writeBudgetSummary(os.Stdout, result)
+	writeGapReport(os.Stdout, rawSessions, result)
+	if hasCoverage(preferences) {
+		writeCoverageSummary(os.Stdout, result)
+	}
+}
+
+// plannerFlags holds the flags shared by planning and serve.
+type plannerFlags struct {
+	sessionsPath      *string
+	wantPath          *string
+	coveragePath      *string
+	bufferMinutes     *int
+	blackoutsPath     *string
+	blackoutTolerance *int
+	togetherBonus     *int
+	travelPath        *string
+	drivers           *string
+	icsPaths          *string
+	icsZone           *string
+	scalePath         *string
+	aliasesPath       *string
+	backups           *int
+	expected          *bool
+	successPath       *string
+	historyPath       *string
+	overbook          *bool
+	categoriesPath    *string
+	diversityBonus    *int
+	rulesPath         *string
+	commitmentsPath   *string
+	fairness          *string
+	maxScoreGap       *int
+}
+
+func registerPlannerFlags(flags *flag.FlagSet) plannerFlags {
+	return plannerFlags{
+		sessionsPath:      flags.String(flagSessionsParameterNameLiteral, emptyLiteral, emptyLiteral),
+		wantPath:          flags.String(flagWantParameterNameLiteral, emptyLiteral, emptyLiteral),
+		coveragePath:      flags.String(flagCoverageParameterNameLiteral, emptyLiteral, flagCoverageParameterUsageLiteral),
+		bufferMinutes:     flags.Int(flagBufferParameterNameLiteral, planner.DefaultBufferMinutes, flagBufferParameterUsageLiteral),
+		blackoutsPath:     flags.String(flagBlackoutsParameterNameLiteral, emptyLiteral, flagBlackoutsParameterUsageLiteral),
+		blackoutTolerance: flags.Int(flagBlackoutToleranceNameLiteral, 0, flagBlackoutToleranceUsageLiteral),
+		togetherBonus:     flags.Int(flagTogetherBonusParameterNameLiteral, planner.DefaultTogethernessBonus, flagTogetherBonusParameterUsageLiteral),
+		travelPath:        flags.String(flagTravelParameterNameLiteral, emptyLiteral, flagTravelParameterUsageLiteral),
+		drivers:           flags.String(flagDriversParameterNameLiteral, emptyLiteral, flagDriversParameterUsageLiteral),
+		icsPaths:          flags.String(flagICSParameterNameLiteral, emptyLiteral, flagICSParameterUsageLiteral),
+		icsZone:           flags.String(flagICSZoneParameterNameLiteral, emptyLiteral, flagICSZoneParameterUsageLiteral),
+		scalePath:         flags.String(flagScaleParameterNameLiteral, emptyLiteral, flagScaleParameterUsageLiteral),
+		aliasesPath:       flags.String(flagAliasesParameterNameLiteral, emptyLiteral, flagAliasesParameterUsageLiteral),
+		backups:           flags.Int(flagBackupsParameterNameLiteral, planner.DefaultBackups, flagBackupsParameterUsageLiteral),
+		expected:          flags.Bool(flagExpectedParameterNameLiteral, false, flagExpectedParameterUsageLiteral),
+		successPath:       flags.String(flagSuccessParameterNameLiteral, emptyLiteral, flagSuccessParameterUsageLiteral),
+		historyPath:       flags.String(flagHistoryParameterNameLiteral, emptyLiteral, flagHistoryParameterUsageLiteral),
+		overbook:          flags.Bool(flagOverbookParameterNameLiteral, false, flagOverbookParameterUsageLiteral),
+		categoriesPath:    flags.String(flagCategoriesParameterNameLiteral, emptyLiteral, flagCategoriesParameterUsageLiteral),
+		diversityBonus:    flags.Int(flagDiversityBonusParameterNameLiteral, 0, flagDiversityBonusParameterUsageLiteral),
+		rulesPath:         flags.String(flagRulesParameterNameLiteral, emptyLiteral, flagRulesParameterUsageLiteral),
+		commitmentsPath:   flags.String(flagCommitmentsParameterNameLiteral, emptyLiteral, flagCommitmentsParameterUsageLiteral),
+		fairness:          flags.String(flagFairnessParameterNameLiteral, emptyLiteral, flagFairnessParameterUsageLiteral),
+		maxScoreGap:       flags.Int(flagMaxScoreGapParameterNameLiteral, 0, flagMaxScoreGapParameterUsageLiteral),
+	}
+}
+
+func (values plannerFlags) loadScale() (planner.PriorityScale, error) {
+	if *values.scalePath == emptyLiteral {
+		return planner.DefaultPriorityScale(), nil
+	}
+	return loadPriorityScale(*values.scalePath)
+}
+
+func (values plannerFlags) loadAliases() (map[string]string, error) {
+	if *values.aliasesPath == emptyLiteral {
+		return map[string]string{}, nil
+	}
+	return loadAliasFile(*values.aliasesPath)
+}
+
+// buildOptions reads the travel and calendar files into planner options, and
+// returns a copy of want whose children also carry the coverage windows and
+// blackouts of the -coverage and -blackouts files.
+func (values plannerFlags) buildOptions(want planner.Preferences) (planner.Options, planner.Preferences, error) {
+	options := planner.DefaultOptions()
+	options.BufferMinutes = *values.bufferMinutes
+	options.BlackoutToleranceDays = *values.blackoutTolerance
+	options.TogethernessBonus = *values.togetherBonus
+	options.Backups = *values.backups
+	options.OverbookSpots = *values.overbook
+	options.DiversityBonus = *values.diversityBonus
+	options.MaxScoreGap = *values.maxScoreGap
+	fairness, fairnessError := parseFairness(*values.fairness)
+	if fairnessError != nil {
+		return planner.Options{}, planner.Preferences{}, fairnessError
+	}
+	options.Fairness = fairness
+	if boundsError := checkOptionBounds([]optionBound{
+		{"-" + flagBufferParameterNameLiteral, options.BufferMinutes, 0, minutesPerDay},
+		{"-" + flagBlackoutToleranceNameLiteral, options.BlackoutToleranceDays, 0, math.MaxInt},
+		{"-" + flagTogetherBonusParameterNameLiteral, options.TogethernessBonus, 0, math.MaxInt},
+		{"-" + flagDiversityBonusParameterNameLiteral, options.DiversityBonus, 0, math.MaxInt},
+		{"-" + flagBackupsParameterNameLiteral, options.Backups, 0, math.MaxInt},
+		{"-" + flagMaxScoreGapParameterNameLiteral, options.MaxScoreGap, 0, math.MaxInt},
+	}); boundsError != nil {
+		return planner.Options{}, planner.Preferences{}, boundsError
+	}
+	if *values.rulesPath != emptyLiteral {
+		rules, rulesError := loadRulesFile(*values.rulesPath)
+		if rulesError != nil {
+			return planner.Options{}, planner.Preferences{}, rulesError
+		}
+		options.Rules = rules
+	}
+
+	preferences := want
+	preferences.Children = append([]planner.Child(nil), want.Children...)
+	childNames := want.ChildNames()
+	if *values.coveragePath != emptyLiteral {
+		coverageWindows, coverageError := loadCoverageFile(*values.coveragePath, childNames)
+		if coverageError != nil {
+			return planner.Options{}, planner.Preferences{}, coverageError
+		}
+		for childIndex := range preferences.Children {
+			child := &preferences.Children[childIndex]
+			child.Coverage = append(append([]planner.WeeklyWindow(nil), coverageWindows[child.Name]...), child.Coverage...)
+		}
+	}
+	if *values.blackoutsPath != emptyLiteral {
+		blackouts, blackoutError := loadBlackoutFile(*values.blackoutsPath, childNames)
+		if blackoutError != nil {
+			return planner.Options{}, planner.Preferences{}, blackoutError
+		}
+		for childIndex := range preferences.Children {
+			child := &preferences.Children[childIndex]
+			child.Blackouts = append(append([]planner.Blackout(nil), blackouts[child.Name]...), child.Blackouts...)
+		}
+	}
+	if *values.commitmentsPath != emptyLiteral {
+		commitments, commitmentsError := loadCommitmentsFile(*values.commitmentsPath, childNames)
+		if commitmentsError != nil {
+			return planner.Options{}, planner.Preferences{}, commitmentsError
+		}
+		for childIndex := range preferences.Children {
+			child := &preferences.Children[childIndex]
+			child.Commitments = append(append([]planner.Commitment(nil), commitments[child.Name]...), child.Commitments...)
+		}
+	}
+	if *values.drivers != emptyLiteral {
+		driverCounts, driversError := parseDriverCounts(*values.drivers)
+		if driversError != nil {
+			return planner.Options{}, planner.Preferences{}, driversError
+		}
+		options.DriversByWeekday = driverCounts
+	}
+	if *values.travelPath != emptyLiteral {
+		travelMinutes, travelError := loadTravelFile(*values.travelPath)
+		if travelError != nil {
+			return planner.Options{}, planner.Preferences{}, travelError
+		}
+		options.TravelMinutesByLocation = travelMinutes
+	}
+	if *values.expected || *values.successPath != emptyLiteral || *values.historyPath != emptyLiteral {
+		options.SuccessProbabilities = planner.DefaultSuccessProbabilities()
+		if *values.successPath != emptyLiteral {
+			if successError := loadSuccessFile(*values.successPath, options.SuccessProbabilities); successError != nil {
+				return planner.Options{}, planner.Preferences{}, successError
+			}
+		}
+		if *values.historyPath != emptyLiteral {
+			if historyError := learnSuccessFile(*values.historyPath, options.SuccessProbabilities); historyError != nil {
+				return planner.Options{}, planner.Preferences{}, historyError
+			}
+		}
+	}
+	if *values.icsPaths != emptyLiteral {
+		var zone *time.Location
+		if *values.icsZone != emptyLiteral {
+			loaded, zoneError := time.LoadLocation(*values.icsZone)
+			if zoneError != nil {
+				return planner.Options{}, planner.Preferences{}, zoneError
+			}
+			zone = loaded
+		}
+		calendars, calendarError := loadBusyCalendars(*values.icsPaths, zone)
+		if calendarError != nil {
+			return planner.Options{}, planner.Preferences{}, calendarError
+		}
+		options.DriverCalendars = calendars
+	}
+	return options, preferences, nil
+}
+
+// optionBound is a numeric planner setting and the range it must lie in;
+// name is the flag or API field the value came from.
+type optionBound struct {
+	name    string
+	value   int
+	minimum int
+	maximum int
+}
+
+// checkOptionBounds reports the first value outside its range.
+func checkOptionBounds(bounds []optionBound) error {
+	for _, bound := range bounds {
+		switch {
+		case bound.value >= bound.minimum && bound.value <= bound.maximum:
+		case bound.maximum == math.MaxInt:
+			return fmt.Errorf("%s %d is below %d", bound.name, bound.value, bound.minimum)
+		default:
+			return fmt.Errorf("%s %d is not between %d and %d", bound.name, bound.value, bound.minimum, bound.maximum)
+		}
+	}
+	return nil
+}
+
+// hasCoverage reports whether any child has coverage windows, which switches
+// the text output to include the coverage summary.
+func hasCoverage(preferences planner.Preferences) bool {
+	for _, child := range preferences.Children {
+		if len(child.Coverage) > 0 {
+			return true
+		}
+	}
+	return false
 }
 
-// loadWantFile parses want.csv.
-func loadWantFile(wantCSVPath string) wantFileData {
+// loadWantCSV parses want.csv, resolving priorities against scale.
+// Every priority cell the scale does not recognise is reported with its row and column.
+func loadWantCSV(wantCSVPath string, scale planner.PriorityScale) (planner.Preferences, error) {
 	fileHandle, openError := os.Open(wantCSVPath)
 	if openError != nil {
-		panic(openError)
+		return planner.Preferences{}, openError
 	}
 	defer fileHandle.Close()
 
@@ -141,8 +477,13 @@ func loadWantFile(wantCSVPath string) wantFileData {
 
 	childAgesByName := map[string]int{}
 	sessionPriorityByChild := map[string]map[string]string{}
+	type unknownPriority struct {
+		rowNumber, columnNumber int
+		message                 string
+	}
+	var unknownPriorities []unknownPriority
 
-	for {
+	for rowNumber := 2; ; rowNumber++ {
 		row, readError := csvReader.Read()
 		if readError != nil {
 			break
@@ -156,12 +497,19 @@ func loadWantFile(wantCSVPath string) wantFileData {
 				}
 			}
 
-			priorityValue := priorityNoLiteral
+			priorityValue := planner.PriorityNo
 			if indices.priority < len(row) {
 				if priorityText := strings.TrimSpace(row[indices.priority]); priorityText != emptyLiteral {
 					priorityValue = priorityText
 				}
 			}
+			if _, _, known := scale.Lookup(priorityValue); !known {
+				unknownPriorities = append(unknownPriorities, unknownPriority{
+					rowNumber:    rowNumber,
+					columnNumber: indices.priority + 1,
+					message:      fmt.Sprintf(unknownPriorityErrorFormat, rowNumber, indices.priority+1, headerRow[indices.priority], priorityValue),
+				})
+			}
 
 			if sessionPriorityByChild[sessionName] == nil {
 				sessionPriorityByChild[sessionName] = map[string]string{}
@@ -170,372 +518,244 @@ func loadWantFile(wantCSVPath string) wantFileData {
 		}
 	}
 
-	var childNamesSorted []string
-	for childName := range childAgesByName {
-		childNamesSorted = append(childNamesSorted, childName)
-	}
-	sort.Strings(childNamesSorted)
-
-	return wantFileData{
-		childAgesByName:        childAgesByName,
-		childNamesSorted:       childNamesSorted,
-		sessionPriorityByChild: sessionPriorityByChild,
+	var children []planner.Child
+	for childName, age := range childAgesByName {
+		children = append(children, planner.Child{Name: childName, Age: age})
 	}
-}
+	sort.Slice(children, func(i, j int) bool { return children[i].Name < children[j].Name })
 
-// transformRawSessions converts scraper JSON to Session slice.
-func transformRawSessions(jsonPath string, want wantFileData) []Session {
-	jsonBytes, readError := os.ReadFile(jsonPath)
-	if readError != nil {
-		panic(readError)
-	}
-
-	var rawData []map[string]interface{}
-	_ = json.Unmarshal(jsonBytes, &rawData)
-
-	getStringField := func(m map[string]interface{}, key string) string {
-		if v, ok := m[key]; ok {
-			if s, ok := v.(string); ok {
-				return s
-			}
-		}
-		return emptyLiteral
-	}
-
-	getInt64Field := func(m map[string]interface{}, key string) int64 {
-		if v, ok := m[key]; ok {
-			switch tv := v.(type) {
-			case float64:
-				return int64(tv)
-			case json.Number:
-				i, _ := tv.Int64()
-				return i
+	if len(unknownPriorities) > 0 {
+		sort.Slice(unknownPriorities, func(i, j int) bool {
+			if unknownPriorities[i].rowNumber != unknownPriorities[j].rowNumber {
+				return unknownPriorities[i].rowNumber < unknownPriorities[j].rowNumber
 			}
+			return unknownPriorities[i].columnNumber < unknownPriorities[j].columnNumber
+		})
+		messages := make([]string, 0, len(unknownPriorities))
+		for _, unknown := range unknownPriorities {
+			messages = append(messages, unknown.message)
 		}
-		return 0
+		return planner.Preferences{}, fmt.Errorf("%s: unknown priorities\n  %s", wantCSVPath, strings.Join(messages, "\n  "))
 	}
 
-	var sessions []Session
-	for _, rawEntry := range rawData {
-		title := getStringField(rawEntry, "title")
-		if title == emptyLiteral {
-			continue
-		}
-		startUnix := getInt64Field(rawEntry, "startDateUnix")
-		endUnix := getInt64Field(rawEntry, "endDateUnix")
-		startTimeMilitary := getStringField(rawEntry, "startTime")
-		endTimeMilitary := getStringField(rawEntry, "endTime")
-
-		var daysSlice []string
-		if rawDays, ok := rawEntry["days"].([]interface{}); ok {
-			for _, dayInterface := range rawDays {
-				if dayString, ok := dayInterface.(string); ok {
-					daysSlice = append(daysSlice, dayString)
-				}
-			}
-		}
-
-		var minimumAgePointer, maximumAgePointer *int
-		if value, ok := rawEntry["minAge"].(float64); ok {
-			temp := int(value)
-			minimumAgePointer = &temp
-		}
-		if value, ok := rawEntry["maxAge"].(float64); ok {
-			temp := int(value)
-			maximumAgePointer = &temp
-		}
-
-		availabilityText := getStringField(rawEntry, "availability")
-
-		prioritiesMap := want.sessionPriorityByChild[title]
-		if prioritiesMap == nil {
-			prioritiesMap = map[string]string{}
-		}
-
-		session := Session{
-			ActivityName:         title,
-			StartDateUnixSeconds: startUnix,
-			EndDateUnixSeconds:   endUnix,
-			DaysOfWeek:           daysSlice,
-			StartTimeMilitary:    startTimeMilitary,
-			EndTimeMilitary:      endTimeMilitary,
-			MinimumAgeInclusive:  minimumAgePointer,
-			MaximumAgeExclusive:  maximumAgePointer,
-			AvailabilityText:     availabilityText,
-			PageURL:              getStringField(rawEntry, "pageUrl"),
-			InterestedPriorities: prioritiesMap,
-			startDate:            time.Unix(startUnix, 0),
-			endDate:              time.Unix(endUnix, 0),
-			startClockMinutes:    militaryTimeToMinutes(startTimeMilitary),
-			endClockMinutes:      militaryTimeToMinutes(endTimeMilitary),
-		}
-		sessions = append(sessions, session)
-	}
-	return sessions
+	return planner.Preferences{
+		Children:   children,
+		Priorities: sessionPriorityByChild,
+		Scale:      scale,
+	}, nil
 }
 
-// buildOptimizedPlans selects joint and individual sessions.
-func buildOptimizedPlans(allSessions []Session, want wantFileData) (map[string]*childPlan, []Session) {
-	plansByChild := map[string]*childPlan{}
-	for _, childName := range want.childNamesSorted {
-		plansByChild[childName] = &childPlan{enrolledActivitiesSet: map[string]struct{}{}}
+// loadSessions reads scraper JSON from a file.
+func loadSessions(jsonPath string) ([]planner.Session, error) {
+	jsonBytes, readError := os.ReadFile(jsonPath)
+	if readError != nil {
+		return nil, readError
 	}
-
-	type scoredSession struct {
-		sessionInstance Session
-		totalScore      int
+	sessions, parseError := planner.ParseSessions(jsonBytes)
+	if parseError != nil {
+		return nil, fmt.Errorf("%s: %w", jsonPath, parseError)
 	}
+	return sessions, nil
+}
 
-	var candidateJointSessions []scoredSession
-	for _, session := range allSessions {
-		if !availabilityIsOpen(session.AvailabilityText) {
-			continue
-		}
-		totalPriorityScore := 0
-		allChildrenInterested := true
-
-		for _, childName := range want.childNamesSorted {
-			priorityScore := priorityScoreByWord[session.InterestedPriorities[childName]]
-			if priorityScore == 0 || !ageIsWithinBounds(want.childAgesByName[childName], session.MinimumAgeInclusive, session.MaximumAgeExclusive) {
-				allChildrenInterested = false
-				break
+// buildExport assembles the -json output for a finished plan.
+func buildExport(allSessions []planner.Session, result *planner.Result, preferences planner.Preferences) exportJSON {
+	exportData := exportJSON{Children: map[string][]simpleSessionJSON{}, Candidates: map[string][]simpleSessionJSON{}, Satisfaction: exportSatisfaction(result), Rejections: result.Rejections}
+	for _, childName := range result.ChildNames {
+		for _, commitment := range result.Plans[childName].Child.Commitments {
+			if exportData.Commitments == nil {
+				exportData.Commitments = map[string][]simpleSessionJSON{}
 			}
-			totalPriorityScore += priorityScore
-		}
-
-		if allChildrenInterested {
-			candidateJointSessions = append(candidateJointSessions, scoredSession{sessionInstance: session, totalScore: totalPriorityScore})
+			exportData.Commitments[childName] = append(exportData.Commitments[childName], exportCommitment(commitment))
 		}
 	}
 
-	sort.Slice(candidateJointSessions, func(i, j int) bool {
-		if candidateJointSessions[i].totalScore != candidateJointSessions[j].totalScore {
-			return candidateJointSessions[i].totalScore > candidateJointSessions[j].totalScore
-		}
-		return candidateJointSessions[i].sessionInstance.startDate.Before(candidateJointSessions[j].sessionInstance.startDate)
-	})
-
-	var chosenJointSessions []Session
-	for _, candidate := range candidateJointSessions {
-		sessionFits := true
-		for _, plan := range plansByChild {
-			if !plan.sessionFitsInPlan(candidate.sessionInstance) {
-				sessionFits = false
-				break
-			}
-		}
-		if sessionFits {
-			chosenJointSessions = append(chosenJointSessions, candidate.sessionInstance)
-			for _, plan := range plansByChild {
-				plan.addSession(candidate.sessionInstance)
-			}
-		}
-	}
-
-	jointActivitySet := map[string]struct{}{}
-	for _, session := range chosenJointSessions {
-		jointActivitySet[session.ActivityName] = struct{}{}
+	for _, session := range result.Joint {
+		jointEntry := exportSession(preferences, session, result.ChildNames)
+		jointEntry.MissedDates = formatDates(result.JointMissedDates(session))
+		jointEntry.Probability = exportProbability(result, session)
+		jointEntry.Overbooked = result.Overbooked(session)
+		jointEntry.Backups = exportBackups(preferences, result.BackupsFor(session, result.ChildNames), result.ChildNames)
+		exportData.Joint = append(exportData.Joint, jointEntry)
 	}
 
-	type individualCandidate struct {
-		sessionInstance Session
-		childName       string
-		priorityScore   int
-	}
-
-	var individualPool []individualCandidate
-	for _, session := range allSessions {
-		if !availabilityIsOpen(session.AvailabilityText) {
-			continue
-		}
-		if _, alreadyJoint := jointActivitySet[session.ActivityName]; alreadyJoint {
-			continue
-		}
-		for _, childName := range want.childNamesSorted {
-			priorityScore := priorityScoreByWord[session.InterestedPriorities[childName]]
-			if priorityScore == 0 || !ageIsWithinBounds(want.childAgesByName[childName], session.MinimumAgeInclusive, session.MaximumAgeExclusive) {
+	for _, childName := range result.ChildNames {
+		plan := result.Plans[childName]
+		for _, session := range plan.Sessions {
+			if result.IsJoint(session) {
 				continue
 			}
-			individualPool = append(individualPool, individualCandidate{sessionInstance: session, childName: childName, priorityScore: priorityScore})
+			childEntry := exportSession(preferences, session, []string{childName})
+			childEntry.MissedDates = formatDates(plan.MissedDates[session.Key()])
+			childEntry.With = result.SiblingsSharing(childName, session)
+			childEntry.Probability = exportProbability(result, session)
+			childEntry.Overbooked = result.Overbooked(session)
+			childEntry.Backups = exportBackups(preferences, plan.Backups[session.Key()], []string{childName})
+			exportData.Children[childName] = append(exportData.Children[childName], childEntry)
 		}
-	}
-
-	sort.Slice(individualPool, func(i, j int) bool {
-		if individualPool[i].priorityScore != individualPool[j].priorityScore {
-			return individualPool[i].priorityScore > individualPool[j].priorityScore
-		}
-		return individualPool[i].sessionInstance.startDate.Before(individualPool[j].sessionInstance.startDate)
-	})
-
-	for _, candidate := range individualPool {
-		plan := plansByChild[candidate.childName]
-		if plan.sessionFitsInPlan(candidate.sessionInstance) {
-			plan.addSession(candidate.sessionInstance)
+		for _, session := range allSessions {
+			if preferences.Wants(childName, session) && result.SuccessProbability(session) > 0 && !plan.Has(session) {
+				exportData.Candidates[childName] = append(exportData.Candidates[childName], exportSession(preferences, session, []string{childName}))
+			}
 		}
 	}
-
-	return plansByChild, chosenJointSessions
+	return exportData
 }
 
 // writeJSONOutput persists schedule to file.
-func writeJSONOutput(outputPath string, plans map[string]*childPlan, jointSessions []Session, childNames []string) {
-	exportData := exportJSON{Children: map[string][]simpleSessionJSON{}}
-
-	sort.Slice(jointSessions, func(i, j int) bool { return jointSessions[i].startDate.Before(jointSessions[j].startDate) })
-	for _, session := range jointSessions {
-		exportData.Joint = append(exportData.Joint, simpleSessionJSON{
-			Activity:  session.ActivityName,
-			StartDate: session.startDate.Format(dateLayoutISOLiteral),
-			EndDate:   session.endDate.Format(dateLayoutISOLiteral),
-			URL:       session.PageURL,
-		})
-	}
-
-	for _, childName := range childNames {
-		plan := plans[childName]
-		sort.Slice(plan.scheduledSessions, func(i, j int) bool {
-			return plan.scheduledSessions[i].startDate.Before(plan.scheduledSessions[j].startDate)
-		})
-		for _, session := range plan.scheduledSessions {
-			if _, sessionIsJoint := exportData.findJointActivity(session.ActivityName); sessionIsJoint {
-				continue
-			}
-			exportData.Children[childName] = append(exportData.Children[childName], simpleSessionJSON{
-				Activity:  session.ActivityName,
-				StartDate: session.startDate.Format(dateLayoutISOLiteral),
-				EndDate:   session.endDate.Format(dateLayoutISOLiteral),
-				URL:       session.PageURL,
-			})
-		}
-	}
-
+func writeJSONOutput(outputPath string, exportData exportJSON) error {
 	fileHandle, createError := os.Create(outputPath)
 	if createError != nil {
-		panic(createError)
+		return createError
 	}
 	defer fileHandle.Close()
 
 	jsonEncoder := json.NewEncoder(fileHandle)
 	jsonEncoder.SetIndent(emptyLiteral, "  ")
-	_ = jsonEncoder.Encode(exportData)
+	if encodeError := jsonEncoder.Encode(exportData); encodeError != nil {
+		return encodeError
+	}
 
 	fmt.Println(outputWrittenPrefixLiteral, outputPath)
+	return nil
 }
 
-// printTextOutput prints schedule to stdout.
-func printTextOutput(jointSessions []Session, plans map[string]*childPlan, childNames []string) {
-	fmt.Println(jointScheduleHeadingLiteral)
+// writeTextOutput writes the schedule in plain text.
+func writeTextOutput(writer io.Writer, result *planner.Result) {
+	fmt.Fprintln(writer, jointScheduleHeadingLiteral)
 
-	sort.Slice(jointSessions, func(i, j int) bool { return jointSessions[i].startDate.Before(jointSessions[j].startDate) })
-	for _, session := range jointSessions {
-		fmt.Println(session.ActivityName, session.startDate.Format(dateLayoutISOLiteral), session.endDate.Format(dateLayoutISOLiteral), session.PageURL)
+	for _, session := range result.Joint {
+		fmt.Fprintln(writer, session.ActivityName, session.StartDate().Format(dateLayoutISOLiteral), session.EndDate().Format(dateLayoutISOLiteral), session.PageURL+
+			missedDatesSuffix(result.JointMissedDates(session))+
+			spotsSuffix(session, result.SpotsTaken(session))+
+			riskSuffix(result.SuccessProbability(session)))
+		writeBackups(writer, result.BackupsFor(session, result.ChildNames))
 	}
 
-	fmt.Println()
-
-	jointActivitySet := map[string]struct{}{}
-	for _, session := range jointSessions {
-		jointActivitySet[session.ActivityName] = struct{}{}
-	}
+	fmt.Fprintln(writer)
 
-	for _, childName := range childNames {
-		plan := plans[childName]
-		sort.Slice(plan.scheduledSessions, func(i, j int) bool {
-			return plan.scheduledSessions[i].startDate.Before(plan.scheduledSessions[j].startDate)
-		})
-		fmt.Println(childName, childScheduleHeadingSuffixLiteral)
-		for _, session := range plan.scheduledSessions {
-			if _, sessionIsJoint := jointActivitySet[session.ActivityName]; sessionIsJoint {
+	for _, childName := range result.ChildNames {
+		plan := result.Plans[childName]
+		fmt.Fprintln(writer, childName, childScheduleHeadingSuffixLiteral)
+		for _, session := range plan.Sessions {
+			if result.IsJoint(session) {
 				continue
 			}
-			fmt.Println(session.ActivityName, session.startDate.Format(dateLayoutISOLiteral), session.endDate.Format(dateLayoutISOLiteral), session.PageURL)
+			fmt.Fprintln(writer, session.ActivityName, session.StartDate().Format(dateLayoutISOLiteral), session.EndDate().Format(dateLayoutISOLiteral), session.PageURL+
+				missedDatesSuffix(plan.MissedDates[session.Key()])+
+				siblingsSuffix(result.SiblingsSharing(childName, session))+
+				spotsSuffix(session, result.SpotsTaken(session))+
+				riskSuffix(result.SuccessProbability(session)))
+			writeBackups(writer, plan.Backups[session.Key()])
 		}
-		fmt.Println()
+		writeSatisfaction(writer, result, childName)
+		fmt.Fprintln(writer)
 	}
-}
 
-func (plan *childPlan) sessionFitsInPlan(candidate Session) bool {
-	if _, duplicate := plan.enrolledActivitiesSet[candidate.ActivityName]; duplicate {
-		return false
-	}
-	for _, existing := range plan.scheduledSessions {
-		if sessionsOverlap(existing, candidate) {
-			return false
+	if len(result.Rejections) > 0 {
+		fmt.Fprintln(writer, rejectedHeadingLiteral)
+		for _, explanation := range result.Rejections {
+			fmt.Fprintln(writer, explanation)
 		}
+		fmt.Fprintln(writer)
 	}
-	return true
 }
 
-func (plan *childPlan) addSession(session Session) {
-	plan.scheduledSessions = append(plan.scheduledSessions, session)
-	plan.enrolledActivitiesSet[session.ActivityName] = struct{}{}
-}
-
-func (data exportJSON) findJointActivity(activityName string) (simpleSessionJSON, bool) {
-	for _, joint := range data.Joint {
-		if joint.Activity == activityName {
-			return joint, true
+// writeCoverageSummary writes covered versus required hours per child.
+func writeCoverageSummary(writer io.Writer, result *planner.Result) {
+	for _, childName := range result.ChildNames {
+		plan := result.Plans[childName]
+		covered := 0
+		for _, session := range plan.Sessions {
+			covered += planner.CoveredMinutes(session, plan.Child.Coverage)
+		}
+		fmt.Fprintf(writer, coverageSummaryFormatLiteral, childName, float64(covered)/60, float64(planner.RequiredMinutes(plan.Child.Coverage))/60)
+		if committed := planner.CommittedMinutes(plan.Child.Commitments, plan.Child.Coverage); committed > 0 {
+			fmt.Fprintf(writer, commitmentSummaryFormatLiteral, childName, float64(committed)/60)
 		}
 	}
-	return simpleSessionJSON{}, false
 }
 
-func availabilityIsOpen(availabilityText string) bool {
-	lower := strings.TrimSpace(strings.ToLower(availabilityText))
-	switch lower {
-	case emptyLiteral, availabilityAvailableLiteral, availabilityStartingSoonLiteral:
-		return true
-	default:
-		return strings.Contains(lower, availabilitySpaceLeftIdentifierLiteral) && strings.Contains(lower, availabilityLeftIdentifierLiteral)
+// writeBackups lists a chosen session's fallbacks beneath it.
+func writeBackups(writer io.Writer, backups []planner.Session) {
+	for _, backup := range backups {
+		fmt.Fprintln(writer, backupPrefixLiteral, backup.ActivityName, backup.StartDate().Format(dateLayoutISOLiteral), backup.EndDate().Format(dateLayoutISOLiteral), backup.PageURL+spotsSuffix(backup, 0))
 	}
 }
 
-func ageIsWithinBounds(age int, minPtr, maxPtr *int) bool {
-	minimum := 0
-	maximum := 1<<31 - 1
-	if minPtr != nil {
-		minimum = *minPtr
+// exportProbability is the session's success probability when it is uncertain, else 0 so it is omitted.
+func exportProbability(result *planner.Result, session planner.Session) float64 {
+	if probability := result.SuccessProbability(session); probability < 1 {
+		return probability
 	}
-	if maxPtr != nil {
-		maximum = *maxPtr
+	return 0
+}
+
+func exportBackups(preferences planner.Preferences, backups []planner.Session, childNames []string) []simpleSessionJSON {
+	var entries []simpleSessionJSON
+	for _, backup := range backups {
+		entries = append(entries, exportSession(preferences, backup, childNames))
 	}
-	return age >= minimum && age < maximum
+	return entries
 }
 
-func sessionsOverlap(sessionA, sessionB Session) bool {
-	if sessionA.endDate.Before(sessionB.startDate) || sessionB.endDate.Before(sessionA.startDate) {
-		return false
+func missedDatesSuffix(missedDates []time.Time) string {
+	if len(missedDates) == 0 {
+		return emptyLiteral
 	}
+	return " " + missedDatesPrefixLiteral + " " + strings.Join(formatDates(missedDates), missedDatesSeparatorLiteral)
+}
 
-	shareDay := false
-	for _, dayA := range sessionA.DaysOfWeek {
-		for _, dayB := range sessionB.DaysOfWeek {
-			if dayA == dayB {
-				shareDay = true
-				break
-			}
-		}
-		if shareDay {
-			break
+// exportSession describes session for the JSON output with the priorities of childNames;
+// Score is the highest of them.
+func exportSession(preferences planner.Preferences, session planner.Session, childNames []string) simpleSessionJSON {
+	entry := simpleSessionJSON{
+		Activity:     session.ActivityName,
+		StartDate:    session.StartDate().Format(dateLayoutISOLiteral),
+		EndDate:      session.EndDate().Format(dateLayoutISOLiteral),
+		URL:          session.Link(),
+		Days:         session.DaysOfWeek,
+		StartTime:    session.StartTimeMilitary,
+		EndTime:      session.EndTimeMilitary,
+		Location:     session.Location,
+		Price:        session.Price,
+		Number:       session.ActivityNumber,
+		Registration: session.RegistrationOpens,
+		Availability: session.AvailabilityText,
+		Categories:   session.Categories,
+		Status:       string(session.Availability()),
+		SpotsLeft:    exportSpots(session),
+		MinimumAge:   session.MinimumAgeInclusive,
+		MaximumAge:   session.MaximumAgeExclusive,
+		Priorities:   map[string]string{},
+	}
+	for _, childName := range childNames {
+		if priorityValue := preferences.PriorityOf(childName, session); priorityValue != emptyLiteral {
+			entry.Priorities[childName] = priorityValue
+			entry.Score = max(entry.Score, preferences.Scale.Score(priorityValue))
 		}
 	}
-	if !shareDay {
-		return false
+	if entry.Score > 0 {
+		entry.PriorityLevel = preferences.Scale.Level(entry.Score)
 	}
+	return entry
+}
 
-	if sessionA.endClockMinutes+bufferMinutesBetweenSessions <= sessionB.startClockMinutes ||
-		sessionB.endClockMinutes+bufferMinutesBetweenSessions <= sessionA.startClockMinutes {
-		return false
+// exportCommitment lays a commitment out like a session so the timeline can draw it.
+func exportCommitment(commitment planner.Commitment) simpleSessionJSON {
+	return simpleSessionJSON{
+		Activity:  commitment.Note,
+		StartDate: commitment.FromDate.Format(dateLayoutISOLiteral),
+		EndDate:   commitment.ToDate.Format(dateLayoutISOLiteral),
+		Days:      strings.Split(planner.FormatWeekdays(commitment.Weekdays), planner.WeekdayListSeparator),
+		StartTime: planner.MinutesToMilitaryTime(commitment.StartClockMinutes),
+		EndTime:   planner.MinutesToMilitaryTime(commitment.EndClockMinutes),
 	}
-	return true
 }
 
-func militaryTimeToMinutes(military string) int {
-	parts := strings.Split(military, ":")
-	if len(parts) != 2 {
-		return 0
+func siblingsSuffix(siblings []string) string {
+	if len(siblings) == 0 {
+		return emptyLiteral
 	}
-	hours, _ := strconv.Atoi(parts[0])
-	minutes, _ := strconv.Atoi(parts[1])
-	return hours*60 + minutes
+	return " " + withSiblingsPrefixLiteral + " " + strings.Join(siblings, siblingSeparatorLiteral)
 }
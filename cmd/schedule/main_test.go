@@ -0,0 +1,43 @@
+// cmd/schedule/main_test.go
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"SummerCamp25/planner"
+)
+
+// writeTestFile writes content to name in a fresh temporary directory and returns its path.
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if writeError := os.WriteFile(path, []byte(content), 0o644); writeError != nil {
+		t.Fatal(writeError)
+	}
+	return path
+}
+
+// testSession is a Monday-to-Friday session of the week starting monday, e.g. "2025-06-16".
+func testSession(title, monday, startTime, endTime string) planner.Session {
+	start, _ := time.Parse(dateLayoutISOLiteral, monday)
+	return planner.Session{
+		ActivityName:         title,
+		StartDateUnixSeconds: start.Unix(),
+		EndDateUnixSeconds:   start.AddDate(0, 0, 4).Unix(),
+		DaysOfWeek:           []string{"Mon", "Tue", "Wed", "Thu", "Fri"},
+		StartTimeMilitary:    startTime,
+		EndTimeMilitary:      endTime,
+	}
+}
+
+// testPreferences gives every child age 8, the default scale and the priorities, keyed by title then child.
+func testPreferences(priorities map[string]map[string]string, childNames ...string) planner.Preferences {
+	preferences := planner.Preferences{Priorities: priorities, Scale: planner.DefaultPriorityScale()}
+	for _, childName := range childNames {
+		preferences.Children = append(preferences.Children, planner.Child{Name: childName, Age: 8})
+	}
+	return preferences
+}
@@ -0,0 +1,194 @@
+// cmd/schedule/match.go
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"SummerCamp25/planner"
+)
+
+const (
+	matchMethodExactLiteral      = "exact"
+	matchMethodAliasLiteral      = "alias"
+	matchMethodNormalizedLiteral = "normalized"
+	aliasWantColumnLiteral       = "want"
+	aliasTitleColumnLiteral      = "title"
+	matchReportHeadingLiteral    = "Title matches"
+	matchReportLineFormat        = "  %q → %q (%s)\n"
+	matchReportUnmatchedFormat   = "  %q → no session\n"
+)
+
+// ageSuffixPattern strips age annotations such as "(Ages 6-9)", "(6–9 yrs)" or "ages 5+".
+var ageSuffixPattern = regexp.MustCompile(`(?i)\s*(\([^)]*\d[^)]*\)|\bages?\s*\d+\s*([-–+]\s*\d*)?\s*(yrs?|years?)?)\s*$`)
+
+// titleMatch records which want row a scraped title was attributed to, and how.
+type titleMatch struct {
+	wantTitle    string
+	sessionTitle string
+	method       string
+}
+
+// loadAliasFile reads a Want,Title CSV mapping want-file rows to scraped titles.
+// A want title may be listed on several rows.
+func loadAliasFile(aliasCSVPath string) (map[string]string, error) {
+	fileHandle, openError := os.Open(aliasCSVPath)
+	if openError != nil {
+		return nil, openError
+	}
+	defer fileHandle.Close()
+
+	csvReader := csv.NewReader(fileHandle)
+	headerRow, headerError := csvReader.Read()
+	if headerError != nil {
+		return nil, headerError
+	}
+	wantIndex, titleIndex := -1, -1
+	for columnIndex, headerValue := range headerRow {
+		switch strings.ToLower(strings.TrimSpace(headerValue)) {
+		case aliasWantColumnLiteral:
+			wantIndex = columnIndex
+		case aliasTitleColumnLiteral:
+			titleIndex = columnIndex
+		}
+	}
+	if wantIndex < 0 || titleIndex < 0 {
+		return nil, fmt.Errorf("%s: need %q and %q columns", aliasCSVPath, aliasWantColumnLiteral, aliasTitleColumnLiteral)
+	}
+
+	wantTitleBySessionTitle := map[string]string{}
+	for rowNumber := 2; ; rowNumber++ {
+		row, readError := csvReader.Read()
+		if readError == io.EOF {
+			break
+		}
+		if readError != nil {
+			return nil, readError
+		}
+		wantTitle, sessionTitle := strings.TrimSpace(row[wantIndex]), strings.TrimSpace(row[titleIndex])
+		if wantTitle == emptyLiteral || sessionTitle == emptyLiteral {
+			return nil, fmt.Errorf("%s row %d: empty want or title", aliasCSVPath, rowNumber)
+		}
+		if previous, duplicate := wantTitleBySessionTitle[sessionTitle]; duplicate && previous != wantTitle {
+			return nil, fmt.Errorf("%s row %d: %q is already an alias of %q", aliasCSVPath, rowNumber, sessionTitle, previous)
+		}
+		wantTitleBySessionTitle[sessionTitle] = wantTitle
+	}
+	return wantTitleBySessionTitle, nil
+}
+
+// normalizeTitle folds case, ampersands, punctuation and a trailing age suffix
+// so that "Camp Clay, Paint & Draw (Ages 6-9)" equals "camp clay paint and draw".
+func normalizeTitle(title string) string {
+	stripped := strings.ReplaceAll(title, "&", " and ")
+	for {
+		withoutSuffix := ageSuffixPattern.ReplaceAllString(stripped, emptyLiteral)
+		if withoutSuffix == stripped {
+			break
+		}
+		stripped = withoutSuffix
+	}
+	words := strings.FieldsFunc(strings.ToLower(stripped), func(character rune) bool {
+		return !unicode.IsLetter(character) && !unicode.IsDigit(character)
+	})
+	return strings.Join(words, " ")
+}
+
+// matchWantTitles attributes every scraped title to at most one want title:
+// an exact match first, then the alias table, then equal normalized titles.
+// It also returns the want titles no scraped title matched, sorted.
+func matchWantTitles(wantTitles, sessionTitles []string, aliases map[string]string) ([]titleMatch, []string) {
+	wantSet := map[string]struct{}{}
+	wantTitlesByNormalized := map[string][]string{}
+	var distinctWantTitles []string
+	for _, wantTitle := range wantTitles {
+		if _, duplicate := wantSet[wantTitle]; duplicate {
+			continue
+		}
+		wantSet[wantTitle] = struct{}{}
+		distinctWantTitles = append(distinctWantTitles, wantTitle)
+		normalized := normalizeTitle(wantTitle)
+		wantTitlesByNormalized[normalized] = append(wantTitlesByNormalized[normalized], wantTitle)
+	}
+
+	var matches []titleMatch
+	matchedWantTitles := map[string]struct{}{}
+	for _, sessionTitle := range sessionTitles {
+		match := titleMatch{sessionTitle: sessionTitle}
+		if _, exact := wantSet[sessionTitle]; exact {
+			match.wantTitle, match.method = sessionTitle, matchMethodExactLiteral
+		} else if aliasOf, aliased := aliases[sessionTitle]; aliased {
+			if _, known := wantSet[aliasOf]; !known {
+				continue
+			}
+			match.wantTitle, match.method = aliasOf, matchMethodAliasLiteral
+		} else if candidates := wantTitlesByNormalized[normalizeTitle(sessionTitle)]; len(candidates) == 1 {
+			match.wantTitle, match.method = candidates[0], matchMethodNormalizedLiteral
+		} else {
+			continue
+		}
+		matches = append(matches, match)
+		matchedWantTitles[match.wantTitle] = struct{}{}
+	}
+
+	var unmatched []string
+	for _, wantTitle := range distinctWantTitles {
+		if _, matched := matchedWantTitles[wantTitle]; !matched {
+			unmatched = append(unmatched, wantTitle)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].wantTitle != matches[j].wantTitle {
+			return matches[i].wantTitle < matches[j].wantTitle
+		}
+		return matches[i].sessionTitle < matches[j].sessionTitle
+	})
+	sort.Strings(unmatched)
+	return matches, unmatched
+}
+
+// applyTitleMatches attaches each session to its matched want row, whose priorities then apply to it.
+func applyTitleMatches(sessions []planner.Session, matches []titleMatch) {
+	wantTitleBySessionTitle := map[string]string{}
+	for _, match := range matches {
+		wantTitleBySessionTitle[match.sessionTitle] = match.wantTitle
+	}
+	for sessionIndex := range sessions {
+		wantTitle, matched := wantTitleBySessionTitle[sessions[sessionIndex].ActivityName]
+		if !matched {
+			continue
+		}
+		sessions[sessionIndex].MatchedTitle = wantTitle
+	}
+}
+
+// distinctSessionTitles lists each scraped title once, sorted.
+func distinctSessionTitles(sessions []planner.Session) []string {
+	seen := map[string]struct{}{}
+	var titles []string
+	for _, session := range sessions {
+		if _, duplicate := seen[session.ActivityName]; !duplicate {
+			seen[session.ActivityName] = struct{}{}
+			titles = append(titles, session.ActivityName)
+		}
+	}
+	sort.Strings(titles)
+	return titles
+}
+
+func printMatchReport(matches []titleMatch, unmatched []string) {
+	fmt.Println(matchReportHeadingLiteral)
+	for _, match := range matches {
+		fmt.Printf(matchReportLineFormat, match.wantTitle, match.sessionTitle, match.method)
+	}
+	for _, wantTitle := range unmatched {
+		fmt.Printf(matchReportUnmatchedFormat, wantTitle)
+	}
+	fmt.Println()
+}
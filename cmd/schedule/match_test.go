@@ -0,0 +1,106 @@
+// cmd/schedule/match_test.go
+package main
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"SummerCamp25/planner"
+)
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{title: "Camp Clay, Paint & Draw (Ages 6-9)", want: "camp clay paint and draw"},
+		{title: "camp clay paint and draw", want: "camp clay paint and draw"},
+		{title: "Junior Lifeguards (6–9 yrs)", want: "junior lifeguards"},
+		{title: "Soccer Stars ages 5+", want: "soccer stars"},
+		{title: "Robotics 2 (Level 2) Ages 8-12", want: "robotics 2"},
+		{title: "Robotics 2", want: "robotics 2"},
+	}
+	for _, test := range tests {
+		if normalized := normalizeTitle(test.title); normalized != test.want {
+			t.Errorf("normalizeTitle(%q) = %q, want %q", test.title, normalized, test.want)
+		}
+	}
+}
+
+func TestMatchWantTitles(t *testing.T) {
+	wantTitles := []string{"Art", "Clay & Paint", "Swim", "Robotics", "Art", "Chess", "chess"}
+	sessionTitles := []string{
+		"Art",
+		"Clay and Paint (Ages 6-9)",
+		"Aquatics Level 1",
+		"Aquatics Level 2",
+		"Pottery",
+		"CHESS!",
+	}
+	aliases := map[string]string{
+		"Aquatics Level 1": "Swim",
+		"Aquatics Level 2": "Swim",
+		"Pottery":          "Ceramics",
+	}
+	matches, unmatched := matchWantTitles(wantTitles, sessionTitles, aliases)
+	want := []titleMatch{
+		{wantTitle: "Art", sessionTitle: "Art", method: matchMethodExactLiteral},
+		{wantTitle: "Clay & Paint", sessionTitle: "Clay and Paint (Ages 6-9)", method: matchMethodNormalizedLiteral},
+		{wantTitle: "Swim", sessionTitle: "Aquatics Level 1", method: matchMethodAliasLiteral},
+		{wantTitle: "Swim", sessionTitle: "Aquatics Level 2", method: matchMethodAliasLiteral},
+	}
+	if !reflect.DeepEqual(matches, want) {
+		t.Errorf("matches = %+v, want %+v", matches, want)
+	}
+	// "CHESS!" normalises to two want rows, so it is left for the parent to alias.
+	if wantUnmatched := []string{"Chess", "Robotics", "chess"}; !slices.Equal(unmatched, wantUnmatched) {
+		t.Errorf("unmatched = %q, want %q", unmatched, wantUnmatched)
+	}
+}
+
+func TestApplyTitleMatches(t *testing.T) {
+	const week = "2025-06-16"
+	preferences := testPreferences(map[string]map[string]string{"Swim": {"Alice": planner.PriorityHigh}}, "Alice")
+	sessions := []planner.Session{
+		testSession("Aquatics Level 1", week, "09:00", "12:00"),
+		testSession("Pottery", week, "09:00", "12:00"),
+	}
+	applyTitleMatches(sessions, []titleMatch{{wantTitle: "Swim", sessionTitle: "Aquatics Level 1", method: matchMethodAliasLiteral}})
+	if sessions[0].ActivityKey() != "Swim" || preferences.PriorityOf("Alice", sessions[0]) != planner.PriorityHigh {
+		t.Errorf("matched session = %q with %q, want Swim's priority", sessions[0].ActivityKey(), preferences.PriorityOf("Alice", sessions[0]))
+	}
+	if sessions[1].MatchedTitle != emptyLiteral || preferences.PriorityOf("Alice", sessions[1]) != emptyLiteral {
+		t.Errorf("unmatched session = %q with %q, want it left alone", sessions[1].MatchedTitle, preferences.PriorityOf("Alice", sessions[1]))
+	}
+}
+
+func TestLoadAliasFile(t *testing.T) {
+	aliases, loadError := loadAliasFile(writeTestFile(t, "aliases.csv", "Title,Want\nAquatics Level 1,Swim\n Aquatics Level 2 , Swim \n"))
+	if loadError != nil {
+		t.Fatal(loadError)
+	}
+	if want := map[string]string{"Aquatics Level 1": "Swim", "Aquatics Level 2": "Swim"}; !maps.Equal(aliases, want) {
+		t.Errorf("aliases = %v, want %v", aliases, want)
+	}
+
+	tests := []struct {
+		name      string
+		content   string
+		wantError string
+	}{
+		{name: "missing column", content: "Want,Session\nSwim,Aquatics\n", wantError: `need "want" and "title" columns`},
+		{name: "empty cell", content: "Want,Title\nSwim,\n", wantError: "row 2: empty want or title"},
+		{name: "title aliased twice", content: "Want,Title\nSwim,Aquatics\nDive,Aquatics\n", wantError: `row 3: "Aquatics" is already an alias of "Swim"`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, loadError := loadAliasFile(writeTestFile(t, "aliases.csv", test.content))
+			if loadError == nil || !strings.Contains(loadError.Error(), test.wantError) {
+				t.Errorf("error = %v, want %s", loadError, test.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// cmd/schedule/priority.go
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"SummerCamp25/planner"
+)
+
+const (
+	scaleWordColumnLiteral     = "word"
+	scaleScoreColumnLiteral    = "score"
+	unknownPriorityErrorFormat = "row %d column %d (%s): unknown priority %q"
+)
+
+// loadPriorityScale reads a Word,Score CSV. A Score of "must" makes the word a hard requirement.
+func loadPriorityScale(scaleCSVPath string) (planner.PriorityScale, error) {
+	fileHandle, openError := os.Open(scaleCSVPath)
+	if openError != nil {
+		return planner.PriorityScale{}, openError
+	}
+	defer fileHandle.Close()
+
+	csvReader := csv.NewReader(fileHandle)
+	headerRow, headerError := csvReader.Read()
+	if headerError != nil {
+		return planner.PriorityScale{}, headerError
+	}
+	wordIndex, scoreIndex := -1, -1
+	for columnIndex, headerValue := range headerRow {
+		switch strings.ToLower(strings.TrimSpace(headerValue)) {
+		case scaleWordColumnLiteral:
+			wordIndex = columnIndex
+		case scaleScoreColumnLiteral:
+			scoreIndex = columnIndex
+		}
+	}
+	if wordIndex < 0 || scoreIndex < 0 {
+		return planner.PriorityScale{}, fmt.Errorf("%s: need %q and %q columns", scaleCSVPath, scaleWordColumnLiteral, scaleScoreColumnLiteral)
+	}
+
+	scoreByWord := map[string]int{}
+	var mustWords []string
+	for rowNumber := 2; ; rowNumber++ {
+		row, readError := csvReader.Read()
+		if readError == io.EOF {
+			break
+		}
+		if readError != nil {
+			return planner.PriorityScale{}, readError
+		}
+		word := strings.ToLower(strings.TrimSpace(row[wordIndex]))
+		scoreText := strings.TrimSpace(row[scoreIndex])
+		if strings.EqualFold(scoreText, planner.PriorityMust) {
+			mustWords = append(mustWords, word)
+			continue
+		}
+		score, parseError := strconv.Atoi(scoreText)
+		if parseError != nil || score < 0 {
+			return planner.PriorityScale{}, fmt.Errorf("%s row %d: invalid score %q", scaleCSVPath, rowNumber, scoreText)
+		}
+		scoreByWord[word] = score
+	}
+	return planner.NewPriorityScale(scoreByWord, mustWords), nil
+}
@@ -0,0 +1,43 @@
+// cmd/schedule/priority_test.go
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"SummerCamp25/planner"
+)
+
+func TestLoadPriorityScale(t *testing.T) {
+	scale, loadError := loadPriorityScale(writeTestFile(t, "scale.csv", "Word,Score\nLove,5\nLike,2\nNeed,must\n"))
+	if loadError != nil {
+		t.Fatal(loadError)
+	}
+	if scale.Score("love") != 5 || scale.Score("LIKE") != 2 || !scale.IsMust("Need") {
+		t.Errorf("scale = %+v, want love 5, like 2 and need a Must", scale)
+	}
+	if _, _, known := scale.Lookup(planner.PriorityHigh); known {
+		t.Error("a custom scale still knows the default High")
+	}
+	if _, loadError := loadPriorityScale(writeTestFile(t, "scale.csv", "Word,Score\nLove,lots\n")); loadError == nil || !strings.Contains(loadError.Error(), `row 2: invalid score "lots"`) {
+		t.Errorf("error = %v, want the invalid score on row 2", loadError)
+	}
+}
+
+func TestLoadWantFileReportsUnknownPriorities(t *testing.T) {
+	wantCSV := "Activity,Alice's Age,Alice's Priority,Bob's Age,Bob's Priority\n" +
+		"Art,8,Hihg,6,Low\n" +
+		"Swim,8,High,6,maybe\n"
+	_, loadError := loadWantFile(writeTestFile(t, "want.csv", wantCSV), planner.DefaultPriorityScale())
+	if loadError == nil {
+		t.Fatal("want file with unknown priorities loaded")
+	}
+	for _, want := range []string{
+		`row 2 column 3 (Alice's Priority): unknown priority "Hihg"`,
+		`row 3 column 5 (Bob's Priority): unknown priority "maybe"`,
+	} {
+		if !strings.Contains(loadError.Error(), want) {
+			t.Errorf("error %q does not report %s", loadError, want)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// cmd/schedule/rules.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"SummerCamp25/planner"
+)
+
+const (
+	flagRulesParameterNameLiteral  = "rules"
+	flagRulesParameterUsageLiteral = "path to rules JSON of activity repeats, families, prerequisites and bundles"
+)
+
+// structuredRules is the -rules file. Activities are want titles.
+type structuredRules struct {
+	MaxRepeats    map[string]int      `json:"maxRepeats,omitempty"`
+	Families      []structuredFamily  `json:"families,omitempty"`
+	Prerequisites map[string][]string `json:"prerequisites,omitempty"`
+	Bundles       [][]string          `json:"bundles,omitempty"`
+}
+
+type structuredFamily struct {
+	Name       string   `json:"name"`
+	Activities []string `json:"activities"`
+	Max        int      `json:"max,omitempty"`
+}
+
+// loadRulesFile parses and validates the rules file; all problems are reported together.
+func loadRulesFile(rulesJSONPath string) (planner.Rules, error) {
+	jsonBytes, readError := os.ReadFile(rulesJSONPath)
+	if readError != nil {
+		return planner.Rules{}, readError
+	}
+	var structured structuredRules
+	if decodeError := json.Unmarshal(jsonBytes, &structured); decodeError != nil {
+		return planner.Rules{}, fmt.Errorf("%s: %w", rulesJSONPath, decodeError)
+	}
+
+	var problems []string
+	for activity, repeats := range structured.MaxRepeats {
+		if repeats < 1 {
+			problems = append(problems, fmt.Sprintf("maxRepeats[%q]: must be at least 1", activity))
+		}
+	}
+	rules := planner.Rules{MaxRepeats: structured.MaxRepeats, Prerequisites: structured.Prerequisites, Bundles: structured.Bundles}
+	for familyIndex, family := range structured.Families {
+		location := fmt.Sprintf("families[%d]", familyIndex)
+		switch {
+		case family.Name == emptyLiteral:
+			problems = append(problems, location+": missing name")
+		case len(family.Activities) < 2:
+			problems = append(problems, fmt.Sprintf("%s: %s needs at least two activities", location, family.Name))
+		case family.Max < 0:
+			problems = append(problems, fmt.Sprintf("%s: %s has a negative max", location, family.Name))
+		default:
+			rules.Families = append(rules.Families, planner.ActivityFamily{Name: family.Name, Activities: family.Activities, MaxSessions: family.Max})
+		}
+	}
+	for activity, prerequisites := range structured.Prerequisites {
+		for _, prerequisite := range prerequisites {
+			if prerequisite == activity {
+				problems = append(problems, fmt.Sprintf("prerequisites[%q]: an activity cannot precede itself", activity))
+			}
+		}
+	}
+	for _, cycle := range prerequisiteCycles(structured.Prerequisites) {
+		problems = append(problems, "prerequisites: cycle "+strings.Join(cycle, " → ")+", so none of them can be placed")
+	}
+	for bundleIndex, bundle := range structured.Bundles {
+		if len(bundle) < 2 {
+			problems = append(problems, fmt.Sprintf("bundles[%d]: needs at least two activities", bundleIndex))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return planner.Rules{}, fmt.Errorf("%s: invalid rules:\n  %s", rulesJSONPath, strings.Join(problems, "\n  "))
+	}
+	return rules, nil
+}
+
+// prerequisiteCycles finds the activities that, through their prerequisites,
+// need themselves, each cycle as quoted titles ending where it starts.
+// An activity listed as its own prerequisite is reported elsewhere.
+func prerequisiteCycles(prerequisites map[string][]string) [][]string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := map[string]int{}
+	var path []string
+	var cycles [][]string
+	var visit func(activity string)
+	visit = func(activity string) {
+		state[activity] = visiting
+		path = append(path, activity)
+		for _, prerequisite := range prerequisites[activity] {
+			switch state[prerequisite] {
+			case unvisited:
+				visit(prerequisite)
+			case visiting:
+				if prerequisite == activity {
+					continue
+				}
+				start := len(path) - 1
+				for path[start] != prerequisite {
+					start--
+				}
+				var cycle []string
+				for _, member := range append(path[start:], prerequisite) {
+					cycle = append(cycle, strconv.Quote(member))
+				}
+				cycles = append(cycles, cycle)
+			}
+		}
+		path = path[:len(path)-1]
+		state[activity] = visited
+	}
+	activities := make([]string, 0, len(prerequisites))
+	for activity := range prerequisites {
+		activities = append(activities, activity)
+	}
+	sort.Strings(activities)
+	for _, activity := range activities {
+		if state[activity] == unvisited {
+			visit(activity)
+		}
+	}
+	return cycles
+}
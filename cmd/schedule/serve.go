@@ -0,0 +1,241 @@
+// cmd/schedule/serve.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"SummerCamp25/planner"
+)
+
+const (
+	serveCommandLiteral              = "serve"
+	flagAddressParameterNameLiteral  = "addr"
+	flagAddressParameterUsageLiteral = "address the web UI listens on"
+	defaultServeAddressLiteral       = "127.0.0.1:8080"
+	serveIndexPathLiteral            = "assets/serve.html"
+	serveAssetsDirectoryLiteral      = "assets"
+	serveListeningFormatLiteral      = "serving %s on http://%s\n"
+	contentTypeHeaderLiteral         = "Content-Type"
+	contentTypeJSONLiteral           = "application/json"
+	contentTypeHTMLLiteral           = "text/html; charset=utf-8"
+	serveSavingElsewhereFormat       = "%s cannot hold blackouts; edits are saved to %s\n"
+)
+
+// wantResponse is what the UI edits: the want file plus the rows and values it may use.
+type wantResponse struct {
+	Want       structuredWantFile `json:"want"`
+	Titles     []string           `json:"titles"`
+	Priorities []string           `json:"priorities"`
+	SavedTo    string             `json:"savedTo"`
+}
+
+// planResponse is a fresh plan with its gap report. Error is set when Must
+// activities could not be scheduled; the plan is still returned.
+type planResponse struct {
+	Schedule exportJSON `json:"schedule"`
+	Gaps     string     `json:"gaps"`
+	Error    string     `json:"error,omitempty"`
+}
+
+// planServer keeps the loaded sessions and the current want file between requests.
+type planServer struct {
+	mutex    sync.Mutex
+	values   plannerFlags
+	scale    planner.PriorityScale
+	aliases  map[string]string
+	sessions []planner.Session
+	want     planner.Preferences
+	// savePath is where edits go: -want itself, or a .json want file next to a want.csv.
+	savePath string
+}
+
+// runServe hosts the timeline and a preference editor on localhost.
+func runServe(arguments []string) {
+	flags := flag.NewFlagSet(serveCommandLiteral, flag.ExitOnError)
+	values := registerPlannerFlags(flags)
+	addressFlag := flags.String(flagAddressParameterNameLiteral, defaultServeAddressLiteral, flagAddressParameterUsageLiteral)
+	_ = flags.Parse(arguments)
+
+	if *values.sessionsPath == emptyLiteral || *values.wantPath == emptyLiteral {
+		fmt.Println(fatalMissingFlagsLiteral)
+		return
+	}
+
+	server := &planServer{values: values}
+	scale, scaleError := values.loadScale()
+	if scaleError != nil {
+		fmt.Println("FATAL:", scaleError)
+		return
+	}
+	server.scale = scale
+	aliases, aliasError := values.loadAliases()
+	if aliasError != nil {
+		fmt.Println("FATAL:", aliasError)
+		return
+	}
+	server.aliases = aliases
+	categories, categoriesError := values.loadCategories()
+	if categoriesError != nil {
+		fmt.Println("FATAL:", categoriesError)
+		return
+	}
+	want, wantError := loadWantFile(*values.wantPath, scale)
+	if wantError != nil {
+		fmt.Println("FATAL:", wantError)
+		return
+	}
+	server.want = want
+	savePath, savePathError := serveSavePath(*values.wantPath)
+	if savePathError != nil {
+		fmt.Println("FATAL:", savePathError)
+		return
+	}
+	if savePath != *values.wantPath {
+		fmt.Printf(serveSavingElsewhereFormat, *values.wantPath, savePath)
+	}
+	server.savePath = savePath
+	sessions, sessionsError := loadSessions(*values.sessionsPath)
+	if sessionsError != nil {
+		fmt.Println("FATAL:", sessionsError)
+		return
+	}
+	categories.Tag(sessions)
+	server.sessions = sessions
+
+	assets, _ := fs.Sub(timelineAssets, serveAssetsDirectoryLiteral)
+	mux := http.NewServeMux()
+	mux.Handle("GET /assets/", http.StripPrefix("/assets/", http.FileServer(http.FS(assets))))
+	mux.HandleFunc("GET /{$}", server.handleIndex)
+	mux.HandleFunc("GET /api/want", server.handleGetWant)
+	mux.HandleFunc("PUT /api/want", server.handlePutWant)
+	mux.HandleFunc("POST /api/plan", server.handlePlan)
+
+	fmt.Printf(serveListeningFormatLiteral, *values.wantPath, *addressFlag)
+	log.Fatal(http.ListenAndServe(*addressFlag, mux))
+}
+
+func (server *planServer) handleIndex(writer http.ResponseWriter, _ *http.Request) {
+	page, readError := timelineAssets.ReadFile(serveIndexPathLiteral)
+	if readError != nil {
+		http.Error(writer, readError.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set(contentTypeHeaderLiteral, contentTypeHTMLLiteral)
+	_, _ = writer.Write(page)
+}
+
+func (server *planServer) handleGetWant(writer http.ResponseWriter, _ *http.Request) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	writeJSONResponse(writer, http.StatusOK, server.wantResponse())
+}
+
+// serveSavePath is where serve saves edits to wantPath. A want.csv cannot
+// hold blackouts, so its edits go to a .json want file of the same name,
+// which must not exist yet.
+func serveSavePath(wantPath string) (string, error) {
+	extension := filepath.Ext(wantPath)
+	if strings.EqualFold(extension, structuredWantExtensionLiteral) {
+		return wantPath, nil
+	}
+	savePath := strings.TrimSuffix(wantPath, extension) + structuredWantExtensionLiteral
+	if _, statError := os.Stat(savePath); statError == nil {
+		return emptyLiteral, fmt.Errorf("%s already exists; serve it with -want %s", savePath, savePath)
+	}
+	return savePath, nil
+}
+
+// handlePutWant validates the edited want file, saves it to the save path and makes it current.
+func (server *planServer) handlePutWant(writer http.ResponseWriter, request *http.Request) {
+	var structured structuredWantFile
+	if decodeError := json.NewDecoder(request.Body).Decode(&structured); decodeError != nil {
+		http.Error(writer, decodeError.Error(), http.StatusBadRequest)
+		return
+	}
+	want, validationError := structured.toPreferences(server.scale)
+	if validationError != nil {
+		http.Error(writer, validationError.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	if writeError := writeWantFile(server.savePath, want); writeError != nil {
+		http.Error(writer, writeError.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	server.want = want
+	writeJSONResponse(writer, http.StatusOK, server.wantResponse())
+}
+
+// handlePlan re-plans with the current want file and the files named by the flags.
+func (server *planServer) handlePlan(writer http.ResponseWriter, request *http.Request) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	sessions := server.currentSessions()
+	options, preferences, optionsError := server.values.buildOptions(server.want)
+	if optionsError != nil {
+		http.Error(writer, optionsError.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	result, planError := planner.New(options).Plan(request.Context(), sessions, preferences)
+	if planError != nil {
+		http.Error(writer, planError.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	var gapReport bytes.Buffer
+	writeGapReport(&gapReport, sessions, result)
+	response := planResponse{
+		Schedule: buildExport(sessions, result, preferences),
+		Gaps:     gapReport.String(),
+	}
+	if requirementError := result.RequirementsError(); requirementError != nil {
+		response.Error = requirementError.Error()
+	}
+	writeJSONResponse(writer, http.StatusOK, response)
+}
+
+// currentSessions copies the loaded sessions and matches them to the current want file's rows.
+func (server *planServer) currentSessions() []planner.Session {
+	sessions := append([]planner.Session(nil), server.sessions...)
+	matches, _ := matchWantTitles(server.want.Titles(), distinctSessionTitles(sessions), server.aliases)
+	applyTitleMatches(sessions, matches)
+	return sessions
+}
+
+// wantResponse lists every want row plus the scraped titles no row matches yet.
+func (server *planServer) wantResponse() wantResponse {
+	titles := server.want.Titles()
+	sessionTitles := distinctSessionTitles(server.sessions)
+	matches, _ := matchWantTitles(titles, sessionTitles, server.aliases)
+	matched := map[string]struct{}{}
+	for _, match := range matches {
+		matched[match.sessionTitle] = struct{}{}
+	}
+	for _, sessionTitle := range sessionTitles {
+		if _, isMatched := matched[sessionTitle]; !isMatched {
+			titles = append(titles, sessionTitle)
+		}
+	}
+	return wantResponse{Want: toStructured(server.want), Titles: titles, Priorities: server.scale.Words(), SavedTo: server.savePath}
+}
+
+func writeJSONResponse(writer http.ResponseWriter, status int, value any) {
+	writer.Header().Set(contentTypeHeaderLiteral, contentTypeJSONLiteral)
+	writer.WriteHeader(status)
+	jsonEncoder := json.NewEncoder(writer)
+	jsonEncoder.SetEscapeHTML(false)
+	_ = jsonEncoder.Encode(value)
+}
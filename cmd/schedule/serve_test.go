@@ -0,0 +1,139 @@
+// cmd/schedule/serve_test.go
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"SummerCamp25/planner"
+)
+
+// testPlanServer serves want.csv, written to a fresh temporary directory, with the sessions.
+func testPlanServer(t *testing.T, wantCSV string, sessions []planner.Session) *planServer {
+	t.Helper()
+	wantPath := writeTestFile(t, "want.csv", wantCSV)
+	want, wantError := loadWantFile(wantPath, planner.DefaultPriorityScale())
+	if wantError != nil {
+		t.Fatal(wantError)
+	}
+	savePath, savePathError := serveSavePath(wantPath)
+	if savePathError != nil {
+		t.Fatal(savePathError)
+	}
+	return &planServer{
+		values:   registerPlannerFlags(flag.NewFlagSet(serveCommandLiteral, flag.ContinueOnError)),
+		scale:    planner.DefaultPriorityScale(),
+		aliases:  map[string]string{},
+		sessions: sessions,
+		want:     want,
+		savePath: savePath,
+	}
+}
+
+// serveRequest sends body, encoded as JSON unless nil, to handler and returns the recorded response.
+func serveRequest(t *testing.T, handler http.HandlerFunc, method string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	var requestBody strings.Builder
+	if body != nil {
+		if encodeError := json.NewEncoder(&requestBody).Encode(body); encodeError != nil {
+			t.Fatal(encodeError)
+		}
+	}
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(method, "/", strings.NewReader(requestBody.String())))
+	return recorder
+}
+
+func TestServeSavesEditsNextToWantCSV(t *testing.T) {
+	server := testPlanServer(t, "Activity,Alice's Age,Alice's Priority\nArt,8,High\n", nil)
+	if filepath.Base(server.savePath) != "want.json" {
+		t.Fatalf("save path = %s, want want.json beside want.csv", server.savePath)
+	}
+
+	edited := toStructured(server.want)
+	edited.Children[0].Preferences["Art"] = planner.PriorityLow
+	edited.Children[0].Preferences["Swim"] = planner.PriorityMust
+	edited.Children[0].Blackouts = []structuredBlackout{{From: "2025-07-04", To: "2025-07-04", Note: "Holiday"}}
+	if response := serveRequest(t, server.handlePutWant, http.MethodPut, edited); response.Code != http.StatusOK {
+		t.Fatalf("PUT status = %d: %s", response.Code, response.Body)
+	}
+
+	saved, loadError := loadWantFile(server.savePath, planner.DefaultPriorityScale())
+	if loadError != nil {
+		t.Fatal(loadError)
+	}
+	if saved.Priorities["Art"]["Alice"] != planner.PriorityLow || saved.Priorities["Swim"]["Alice"] != planner.PriorityMust {
+		t.Errorf("saved priorities = %v, want the edits", saved.Priorities)
+	}
+	if alice, _ := saved.ChildNamed("Alice"); len(alice.Blackouts) != 1 || alice.Blackouts[0].Note != "Holiday" {
+		t.Errorf("saved blackouts = %+v, want the holiday", alice.Blackouts)
+	}
+	if server.want.Priorities["Swim"]["Alice"] != planner.PriorityMust {
+		t.Error("the edit did not become the current want file")
+	}
+
+	var current wantResponse
+	response := serveRequest(t, server.handleGetWant, http.MethodGet, nil)
+	if decodeError := json.NewDecoder(response.Body).Decode(&current); decodeError != nil {
+		t.Fatal(decodeError)
+	}
+	if current.SavedTo != server.savePath || current.Want.Children[0].Preferences["Art"] != planner.PriorityLow {
+		t.Errorf("GET = %+v, want the saved edits", current)
+	}
+
+	// A second serve of the same want.csv would overwrite the edits, so it is refused.
+	if _, savePathError := serveSavePath(filepath.Join(filepath.Dir(server.savePath), "want.csv")); savePathError == nil {
+		t.Error("serving want.csv again would overwrite want.json")
+	}
+}
+
+func TestServeRejectsInvalidEdits(t *testing.T) {
+	server := testPlanServer(t, "Activity,Alice's Age,Alice's Priority\nArt,8,High\n", nil)
+	edited := toStructured(server.want)
+	edited.Children[0].Preferences["Art"] = "Hihg"
+	if response := serveRequest(t, server.handlePutWant, http.MethodPut, edited); response.Code != http.StatusUnprocessableEntity {
+		t.Errorf("PUT status = %d, want %d", response.Code, http.StatusUnprocessableEntity)
+	}
+	if _, statError := os.Stat(server.savePath); statError == nil {
+		t.Error("an invalid edit was saved")
+	}
+	if server.want.Priorities["Art"]["Alice"] != planner.PriorityHigh {
+		t.Error("an invalid edit replaced the current want file")
+	}
+}
+
+func TestServePlansWithTheCurrentWantFile(t *testing.T) {
+	const week = "2025-06-16"
+	sessions := []planner.Session{testSession("Art", week, "09:00", "12:00"), testSession("Swim", week, "09:00", "12:00")}
+	server := testPlanServer(t, "Activity,Alice's Age,Alice's Priority\nArt,8,High\nSwim,8,Low\n", sessions)
+
+	planned := func() []string {
+		var plan planResponse
+		response := serveRequest(t, server.handlePlan, http.MethodPost, nil)
+		if decodeError := json.NewDecoder(response.Body).Decode(&plan); decodeError != nil {
+			t.Fatal(decodeError)
+		}
+		// With one child every chosen session is listed as joint.
+		var activities []string
+		for _, session := range plan.Schedule.Joint {
+			activities = append(activities, session.Activity)
+		}
+		return activities
+	}
+	if activities := planned(); len(activities) != 1 || activities[0] != "Art" {
+		t.Fatalf("planned %q, want Art", activities)
+	}
+
+	edited := toStructured(server.want)
+	edited.Children[0].Preferences["Swim"] = planner.PriorityMust
+	serveRequest(t, server.handlePutWant, http.MethodPut, edited)
+	if activities := planned(); len(activities) != 1 || activities[0] != "Swim" {
+		t.Errorf("planned %q after the edit, want Swim", activities)
+	}
+}
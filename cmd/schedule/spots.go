@@ -0,0 +1,41 @@
+// cmd/schedule/spots.go
+package main
+
+import (
+	"fmt"
+
+	"SummerCamp25/planner"
+)
+
+const (
+	flagOverbookParameterNameLiteral  = "overbook"
+	flagOverbookParameterUsageLiteral = "allow more siblings into a session than its spots left and flag it instead"
+	spotsLeftSuffixFormat             = " %d spots left"
+	spotLeftSuffixLiteral             = " 1 spot left"
+	overbookedSuffixFormat            = " overbooked: %d children for %d spots"
+)
+
+// spotsSuffix shows the spots left of a session with a count, and flags it
+// when more of the plan's children attend than there are spots.
+func spotsSuffix(session planner.Session, attendees int) string {
+	spots, known := session.SpotsLeft()
+	switch {
+	case !known:
+		return emptyLiteral
+	case attendees > spots:
+		return fmt.Sprintf(overbookedSuffixFormat, attendees, spots)
+	case spots == 1:
+		return spotLeftSuffixLiteral
+	default:
+		return fmt.Sprintf(spotsLeftSuffixFormat, spots)
+	}
+}
+
+// exportSpots is the spots-left count for -json, nil when the text gives none.
+func exportSpots(session planner.Session) *int {
+	spots, known := session.SpotsLeft()
+	if !known {
+		return nil
+	}
+	return &spots
+}
@@ -0,0 +1,126 @@
+// cmd/schedule/success.go
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"SummerCamp25/planner"
+)
+
+const (
+	flagExpectedParameterNameLiteral  = "expected"
+	flagExpectedParameterUsageLiteral = "plan for expected score, weighting each session by its chance of registering (default table)"
+	flagSuccessParameterNameLiteral   = "success"
+	flagSuccessParameterUsageLiteral  = "path to success CSV (State,Probability) overriding the default chances; implies -expected"
+	flagHistoryParameterNameLiteral   = "history"
+	flagHistoryParameterUsageLiteral  = "path to history CSV (Availability,Registered) of past attempts to learn the chances from; implies -expected"
+	successStateColumnLiteral         = "state"
+	successProbabilityColumnLiteral   = "probability"
+	historyAvailabilityColumnLiteral  = "availability"
+	historyRegisteredColumnLiteral    = "registered"
+	riskSuffixFormat                  = " risk %d%%"
+)
+
+var historySuccessWords = map[string]bool{
+	"yes": true, "true": true, "1": true, "registered": true, "enrolled": true,
+	"no": false, "false": false, "0": false, "failed": false, "waitlisted": false,
+}
+
+// loadSuccessFile reads a State,Probability CSV; states are the planner's AvailabilityStates.
+func loadSuccessFile(successCSVPath string, probabilities map[planner.AvailabilityState]float64) error {
+	rows, readError := readCSVColumns(successCSVPath, successStateColumnLiteral, successProbabilityColumnLiteral)
+	if readError != nil {
+		return readError
+	}
+	for rowIndex, row := range rows {
+		state := planner.AvailabilityState(strings.ToLower(row[0]))
+		if _, known := probabilities[state]; !known {
+			return fmt.Errorf("%s row %d: unknown state %q", successCSVPath, rowIndex+2, row[0])
+		}
+		probability, parseError := strconv.ParseFloat(row[1], 64)
+		if parseError != nil || probability < 0 || probability > 1 {
+			return fmt.Errorf("%s row %d: probability %q is not between 0 and 1", successCSVPath, rowIndex+2, row[1])
+		}
+		probabilities[state] = probability
+	}
+	return nil
+}
+
+// learnSuccessFile replaces each state's chance with the success rate of past
+// attempts in that state. States without history keep their chance.
+func learnSuccessFile(historyCSVPath string, probabilities map[planner.AvailabilityState]float64) error {
+	rows, readError := readCSVColumns(historyCSVPath, historyAvailabilityColumnLiteral, historyRegisteredColumnLiteral)
+	if readError != nil {
+		return readError
+	}
+	attempts := map[planner.AvailabilityState]int{}
+	successes := map[planner.AvailabilityState]int{}
+	for rowIndex, row := range rows {
+		succeeded, known := historySuccessWords[strings.ToLower(row[1])]
+		if !known {
+			return fmt.Errorf("%s row %d: registered %q is not yes or no", historyCSVPath, rowIndex+2, row[1])
+		}
+		state := planner.Session{AvailabilityText: row[0]}.Availability()
+		attempts[state]++
+		if succeeded {
+			successes[state]++
+		}
+	}
+	for state, count := range attempts {
+		probabilities[state] = float64(successes[state]) / float64(count)
+	}
+	return nil
+}
+
+// readCSVColumns returns the trimmed values of two named columns for every data row.
+func readCSVColumns(csvPath, firstColumn, secondColumn string) ([][2]string, error) {
+	fileHandle, openError := os.Open(csvPath)
+	if openError != nil {
+		return nil, openError
+	}
+	defer fileHandle.Close()
+
+	csvReader := csv.NewReader(fileHandle)
+	headerRow, headerError := csvReader.Read()
+	if headerError != nil {
+		return nil, headerError
+	}
+	firstIndex, secondIndex := -1, -1
+	for columnIndex, headerValue := range headerRow {
+		switch strings.ToLower(strings.TrimSpace(headerValue)) {
+		case firstColumn:
+			firstIndex = columnIndex
+		case secondColumn:
+			secondIndex = columnIndex
+		}
+	}
+	if firstIndex < 0 || secondIndex < 0 {
+		return nil, fmt.Errorf("%s: need %q and %q columns", csvPath, firstColumn, secondColumn)
+	}
+
+	var rows [][2]string
+	for {
+		row, readError := csvReader.Read()
+		if readError == io.EOF {
+			break
+		}
+		if readError != nil {
+			return nil, readError
+		}
+		rows = append(rows, [2]string{strings.TrimSpace(row[firstIndex]), strings.TrimSpace(row[secondIndex])})
+	}
+	return rows, nil
+}
+
+// riskSuffix shows the chance a pick fails, when it is not certain.
+func riskSuffix(probability float64) string {
+	if probability >= 1 {
+		return emptyLiteral
+	}
+	return fmt.Sprintf(riskSuffixFormat, int((1-probability)*100+0.5))
+}
@@ -0,0 +1,97 @@
+// cmd/schedule/timeline.go
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+)
+
+const (
+	timelineCommandLiteral            = "timeline"
+	flagScheduleParameterNameLiteral  = "schedule"
+	flagScheduleParameterUsageLiteral = "path to the schedule JSON written by -json"
+	fatalTimelineFlagsLiteral         = "FATAL: timeline needs -schedule and -out"
+	timelineTemplatePathLiteral       = "assets/timeline.html.tmpl"
+	timelineScriptPathLiteral         = "assets/timeline.js"
+	timelineStylePathLiteral          = "assets/timeline.css"
+	timelineDefaultTitleLiteral       = "Summer Camp Schedule"
+	flagTitleParameterNameLiteral     = "title"
+	flagTitleParameterUsageLiteral    = "heading shown above the timeline"
+)
+
+//go:embed assets
+var timelineAssets embed.FS
+
+// timelinePage is everything the timeline template needs; the schedule is
+// serialised into the page so the file works offline and from file://.
+type timelinePage struct {
+	Title    string
+	Schedule exportJSON
+	Script   template.JS
+	Style    template.CSS
+}
+
+// runTimeline renders a schedule JSON into one self-contained HTML file.
+func runTimeline(arguments []string) {
+	flags := flag.NewFlagSet(timelineCommandLiteral, flag.ExitOnError)
+	schedulePathFlag := flags.String(flagScheduleParameterNameLiteral, emptyLiteral, flagScheduleParameterUsageLiteral)
+	outputPathFlag := flags.String(flagOutputParameterNameLiteral, emptyLiteral, emptyLiteral)
+	titleFlag := flags.String(flagTitleParameterNameLiteral, timelineDefaultTitleLiteral, flagTitleParameterUsageLiteral)
+	_ = flags.Parse(arguments)
+
+	if *schedulePathFlag == emptyLiteral || *outputPathFlag == emptyLiteral {
+		fmt.Println(fatalTimelineFlagsLiteral)
+		return
+	}
+
+	scheduleBytes, readError := os.ReadFile(*schedulePathFlag)
+	if readError != nil {
+		fmt.Println("FATAL:", readError)
+		return
+	}
+	var schedule exportJSON
+	if decodeError := json.Unmarshal(scheduleBytes, &schedule); decodeError != nil {
+		fmt.Println("FATAL:", fmt.Errorf("%s: %w", *schedulePathFlag, decodeError))
+		return
+	}
+
+	fileHandle, createError := os.Create(*outputPathFlag)
+	if createError != nil {
+		fmt.Println("FATAL:", createError)
+		return
+	}
+	defer fileHandle.Close()
+
+	if renderError := renderTimeline(fileHandle, *titleFlag, schedule); renderError != nil {
+		fmt.Println("FATAL:", renderError)
+		return
+	}
+	fmt.Println(outputWrittenPrefixLiteral, *outputPathFlag)
+}
+
+// renderTimeline writes the timeline page for schedule, inlining the embedded script and style.
+func renderTimeline(writer io.Writer, title string, schedule exportJSON) error {
+	pageTemplate, parseError := template.ParseFS(timelineAssets, timelineTemplatePathLiteral)
+	if parseError != nil {
+		return parseError
+	}
+	script, scriptError := timelineAssets.ReadFile(timelineScriptPathLiteral)
+	if scriptError != nil {
+		return scriptError
+	}
+	style, styleError := timelineAssets.ReadFile(timelineStylePathLiteral)
+	if styleError != nil {
+		return styleError
+	}
+	return pageTemplate.Execute(writer, timelinePage{
+		Title:    title,
+		Schedule: schedule,
+		Script:   template.JS(script),
+		Style:    template.CSS(style),
+	})
+}
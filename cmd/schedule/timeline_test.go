@@ -0,0 +1,30 @@
+// cmd/schedule/timeline_test.go
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderTimeline(t *testing.T) {
+	schedule := exportJSON{Children: map[string][]simpleSessionJSON{
+		"Alice": {{Activity: "Art </script><b>", StartDate: "2025-06-16", EndDate: "2025-06-20"}},
+	}}
+	var page strings.Builder
+	if renderError := renderTimeline(&page, "Summer & Fall", schedule); renderError != nil {
+		t.Fatal(renderError)
+	}
+	html := page.String()
+	if !strings.Contains(html, "<title>Summer &amp; Fall</title>") {
+		t.Error("the title is not escaped into the page heading")
+	}
+	if strings.Contains(html, "Art </script>") {
+		t.Error("an activity title closed the inline script")
+	}
+	if !strings.Contains(html, `"activity":"Art \u003c/script\u003e\u003cb\u003e"`) {
+		t.Error("the schedule is not serialised into the page")
+	}
+	if strings.Contains(html, "{{") {
+		t.Error("the page still holds template actions")
+	}
+}
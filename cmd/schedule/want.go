@@ -0,0 +1,392 @@
+// cmd/schedule/want.go
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"SummerCamp25/planner"
+)
+
+const (
+	structuredWantExtensionLiteral  = ".json"
+	convertCommandLiteral           = "convert"
+	flagOutputParameterNameLiteral  = "out"
+	fatalConvertFlagsLiteral        = "FATAL: convert needs -want and -out"
+	wantCampColumnLiteral           = "Camp"
+	wantAgeColumnSuffixLiteral      = "'s age"
+	wantPriorityColumnSuffixLiteral = "'s priority"
+)
+
+// structuredWantFile is the JSON alternative to want.csv. Budget caps the
+// family's summed session prices in dollars.
+type structuredWantFile struct {
+	Children  []structuredChild    `json:"children"`
+	Blackouts []structuredBlackout `json:"blackouts,omitempty"`
+	Budget    float64              `json:"budget,omitempty"`
+}
+
+// structuredChild carries one child's settings and per-activity preferences.
+// Birthdate takes precedence over Age; age is then computed at each session's start.
+type structuredChild struct {
+	Name      string               `json:"name"`
+	Birthdate string               `json:"birthdate,omitempty"`
+	Age       int                  `json:"age,omitempty"`
+	Grade     string               `json:"grade,omitempty"`
+	Blackouts []structuredBlackout `json:"blackouts,omitempty"`
+	Coverage  []structuredWindow   `json:"coverage,omitempty"`
+	// Commitments are recurring non-camp blocks such as swim team.
+	Commitments        []structuredCommitment `json:"commitments,omitempty"`
+	MaxSessionsPerWeek int                    `json:"maxSessionsPerWeek,omitempty"`
+	// MaxHoursPerDay and MaxHoursPerWeek cap camp hours; zero means no cap.
+	MaxHoursPerDay      float64 `json:"maxHoursPerDay,omitempty"`
+	MaxHoursPerWeek     float64 `json:"maxHoursPerWeek,omitempty"`
+	MaxConsecutiveWeeks int     `json:"maxConsecutiveWeeks,omitempty"`
+	MinFreeWeeks        int     `json:"minFreeWeeks,omitempty"`
+	// Categories bounds the child's sessions per category of the -categories file.
+	Categories map[string]structuredCategoryLimit `json:"categories,omitempty"`
+	// Friends names children, of this family or another, to share sessions with.
+	Friends     []string          `json:"friends,omitempty"`
+	Preferences map[string]string `json:"preferences"`
+}
+
+type structuredCategoryLimit struct {
+	Min      int `json:"min,omitempty"`
+	Max      int `json:"max,omitempty"`
+	MaxWeeks int `json:"maxWeeks,omitempty"`
+}
+
+type structuredCommitment struct {
+	structuredWindow
+	Note string `json:"note,omitempty"`
+}
+
+type structuredBlackout struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Note string `json:"note,omitempty"`
+}
+
+type structuredWindow struct {
+	Days  string `json:"days"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// loadWantFile reads either want.csv or a structured .json want file.
+func loadWantFile(wantPath string, scale planner.PriorityScale) (planner.Preferences, error) {
+	if strings.EqualFold(filepath.Ext(wantPath), structuredWantExtensionLiteral) {
+		return loadStructuredWantFile(wantPath, scale)
+	}
+	return loadWantCSV(wantPath, scale)
+}
+
+// loadStructuredWantFile parses the JSON want format, validating dates and priorities.
+func loadStructuredWantFile(wantJSONPath string, scale planner.PriorityScale) (planner.Preferences, error) {
+	jsonBytes, readError := os.ReadFile(wantJSONPath)
+	if readError != nil {
+		return planner.Preferences{}, readError
+	}
+	var structured structuredWantFile
+	if decodeError := json.Unmarshal(jsonBytes, &structured); decodeError != nil {
+		return planner.Preferences{}, fmt.Errorf("%s: %w", wantJSONPath, decodeError)
+	}
+	want, validationError := structured.toPreferences(scale)
+	if validationError != nil {
+		return planner.Preferences{}, fmt.Errorf("%s: %w", wantJSONPath, validationError)
+	}
+	return want, nil
+}
+
+// toPreferences validates the structured want file and converts it for planning.
+// All problems are reported together, one per line.
+func (structured structuredWantFile) toPreferences(scale planner.PriorityScale) (planner.Preferences, error) {
+	var problems []string
+	familyBlackouts, familyError := convertBlackouts(structured.Blackouts)
+	if familyError != nil {
+		problems = append(problems, fmt.Sprintf("blackouts: %v", familyError))
+	}
+
+	want := planner.Preferences{Priorities: map[string]map[string]string{}, Scale: scale, Budget: structured.Budget}
+	if structured.Budget < 0 {
+		problems = append(problems, "budget: negative")
+	}
+	for childIndex, child := range structured.Children {
+		location := fmt.Sprintf("children[%d]", childIndex)
+		if child.Name == emptyLiteral {
+			problems = append(problems, location+": missing name")
+			continue
+		}
+		if _, duplicate := want.ChildNamed(child.Name); duplicate {
+			problems = append(problems, fmt.Sprintf("%s: duplicate child %q", location, child.Name))
+			continue
+		}
+
+		if child.MaxSessionsPerWeek < 0 || child.MaxHoursPerDay < 0 || child.MaxHoursPerWeek < 0 || child.MaxConsecutiveWeeks < 0 || child.MinFreeWeeks < 0 {
+			problems = append(problems, fmt.Sprintf("%s: %s has a negative limit", location, child.Name))
+			continue
+		}
+		settings := planner.Child{
+			Name:                child.Name,
+			Grade:               child.Grade,
+			MaxSessionsPerWeek:  child.MaxSessionsPerWeek,
+			MaxMinutesPerDay:    hoursToMinutes(child.MaxHoursPerDay),
+			MaxMinutesPerWeek:   hoursToMinutes(child.MaxHoursPerWeek),
+			MaxConsecutiveWeeks: child.MaxConsecutiveWeeks,
+			MinFreeWeeks:        child.MinFreeWeeks,
+			Friends:             child.Friends,
+		}
+		for category, limit := range child.Categories {
+			if limit.Min < 0 || limit.Max < 0 || limit.MaxWeeks < 0 || (limit.Max > 0 && limit.Min > limit.Max) {
+				problems = append(problems, fmt.Sprintf("%s.categories[%q]: invalid limit", location, category))
+				continue
+			}
+			if settings.CategoryLimits == nil {
+				settings.CategoryLimits = map[string]planner.CategoryLimit{}
+			}
+			settings.CategoryLimits[category] = planner.CategoryLimit{MinSessions: limit.Min, MaxSessions: limit.Max, MaxWeeks: limit.MaxWeeks}
+		}
+		switch {
+		case child.Birthdate != emptyLiteral:
+			birthdate, birthdateError := time.Parse(dateLayoutISOLiteral, child.Birthdate)
+			if birthdateError != nil {
+				problems = append(problems, fmt.Sprintf("%s: invalid birthdate %q", location, child.Birthdate))
+				continue
+			}
+			settings.Birthdate = birthdate
+		case child.Age > 0:
+			settings.Age = child.Age
+		default:
+			problems = append(problems, fmt.Sprintf("%s: %s needs a birthdate or an age", location, child.Name))
+			continue
+		}
+
+		childBlackouts, blackoutError := convertBlackouts(child.Blackouts)
+		if blackoutError != nil {
+			problems = append(problems, fmt.Sprintf("%s: blackouts: %v", location, blackoutError))
+		}
+		settings.Blackouts = append(append(settings.Blackouts, familyBlackouts...), childBlackouts...)
+		for windowIndex, window := range child.Coverage {
+			coverageWindow, windowError := planner.ParseWeeklyWindow(window.Days, window.Start, window.End, window.From, window.To)
+			if windowError != nil {
+				problems = append(problems, fmt.Sprintf("%s.coverage[%d]: %v", location, windowIndex, windowError))
+				continue
+			}
+			settings.Coverage = append(settings.Coverage, coverageWindow)
+		}
+		for commitmentIndex, commitment := range child.Commitments {
+			commitmentWindow, windowError := planner.ParseWeeklyWindow(commitment.Days, commitment.Start, commitment.End, commitment.From, commitment.To)
+			if windowError != nil {
+				problems = append(problems, fmt.Sprintf("%s.commitments[%d]: %v", location, commitmentIndex, windowError))
+				continue
+			}
+			settings.Commitments = append(settings.Commitments, planner.Commitment{WeeklyWindow: commitmentWindow, Note: commitment.Note})
+		}
+		want.Children = append(want.Children, settings)
+
+		for sessionName, priorityValue := range child.Preferences {
+			if _, _, known := scale.Lookup(priorityValue); !known {
+				problems = append(problems, fmt.Sprintf("%s.preferences[%q]: unknown priority %q", location, sessionName, priorityValue))
+				continue
+			}
+			if want.Priorities[sessionName] == nil {
+				want.Priorities[sessionName] = map[string]string{}
+			}
+			want.Priorities[sessionName][child.Name] = priorityValue
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return planner.Preferences{}, fmt.Errorf("invalid want file:\n  %s", strings.Join(problems, "\n  "))
+	}
+	sort.Slice(want.Children, func(i, j int) bool { return want.Children[i].Name < want.Children[j].Name })
+	return want, nil
+}
+
+// hoursToMinutes converts a want file's hour limit to whole minutes.
+func hoursToMinutes(hours float64) int {
+	return int(math.Round(hours * 60))
+}
+
+func hasChildLimits(child planner.Child) bool {
+	return child.MaxSessionsPerWeek > 0 || child.MaxMinutesPerDay > 0 || child.MaxMinutesPerWeek > 0 || child.MaxConsecutiveWeeks > 0 || child.MinFreeWeeks > 0 || len(child.CategoryLimits) > 0
+}
+
+func convertBlackouts(entries []structuredBlackout) ([]planner.Blackout, error) {
+	var blackouts []planner.Blackout
+	for _, entry := range entries {
+		fromDate, fromError := time.Parse(dateLayoutISOLiteral, entry.From)
+		if fromError != nil {
+			return nil, fmt.Errorf("invalid from date %q", entry.From)
+		}
+		toDate, toError := time.Parse(dateLayoutISOLiteral, entry.To)
+		if toError != nil {
+			return nil, fmt.Errorf("invalid to date %q", entry.To)
+		}
+		if toDate.Before(fromDate) {
+			return nil, fmt.Errorf("blackout %s–%s ends before it starts", entry.From, entry.To)
+		}
+		blackouts = append(blackouts, planner.Blackout{FromDate: fromDate, ToDate: toDate, Note: entry.Note})
+	}
+	return blackouts, nil
+}
+
+// toStructured converts loaded preferences back into the JSON format.
+func toStructured(want planner.Preferences) structuredWantFile {
+	structured := structuredWantFile{Budget: want.Budget}
+	for _, settings := range want.Children {
+		child := structuredChild{
+			Name:                settings.Name,
+			Age:                 settings.Age,
+			Grade:               settings.Grade,
+			MaxSessionsPerWeek:  settings.MaxSessionsPerWeek,
+			MaxHoursPerDay:      float64(settings.MaxMinutesPerDay) / 60,
+			MaxHoursPerWeek:     float64(settings.MaxMinutesPerWeek) / 60,
+			MaxConsecutiveWeeks: settings.MaxConsecutiveWeeks,
+			MinFreeWeeks:        settings.MinFreeWeeks,
+			Friends:             settings.Friends,
+			Preferences:         map[string]string{},
+		}
+		for category, limit := range settings.CategoryLimits {
+			if child.Categories == nil {
+				child.Categories = map[string]structuredCategoryLimit{}
+			}
+			child.Categories[category] = structuredCategoryLimit{Min: limit.MinSessions, Max: limit.MaxSessions, MaxWeeks: limit.MaxWeeks}
+		}
+		if !settings.Birthdate.IsZero() {
+			child.Birthdate = settings.Birthdate.Format(dateLayoutISOLiteral)
+			child.Age = 0
+		}
+		for _, blackout := range settings.Blackouts {
+			child.Blackouts = append(child.Blackouts, structuredBlackout{
+				From: blackout.FromDate.Format(dateLayoutISOLiteral),
+				To:   blackout.ToDate.Format(dateLayoutISOLiteral),
+				Note: blackout.Note,
+			})
+		}
+		for _, window := range settings.Coverage {
+			child.Coverage = append(child.Coverage, toStructuredWindow(window))
+		}
+		for _, commitment := range settings.Commitments {
+			child.Commitments = append(child.Commitments, structuredCommitment{structuredWindow: toStructuredWindow(commitment.WeeklyWindow), Note: commitment.Note})
+		}
+		for sessionName, priorityByChild := range want.Priorities {
+			if priorityValue, present := priorityByChild[settings.Name]; present {
+				child.Preferences[sessionName] = priorityValue
+			}
+		}
+		structured.Children = append(structured.Children, child)
+	}
+	return structured
+}
+
+// runConvert rewrites a want file (CSV or JSON) into the format named by -out's extension.
+func runConvert(arguments []string) {
+	flags := flag.NewFlagSet(convertCommandLiteral, flag.ExitOnError)
+	wantPathFlag := flags.String(flagWantParameterNameLiteral, emptyLiteral, emptyLiteral)
+	outputPathFlag := flags.String(flagOutputParameterNameLiteral, emptyLiteral, emptyLiteral)
+	scalePathFlag := flags.String(flagScaleParameterNameLiteral, emptyLiteral, flagScaleParameterUsageLiteral)
+	_ = flags.Parse(arguments)
+
+	if *wantPathFlag == emptyLiteral || *outputPathFlag == emptyLiteral {
+		fmt.Println(fatalConvertFlagsLiteral)
+		return
+	}
+
+	scale := planner.DefaultPriorityScale()
+	if *scalePathFlag != emptyLiteral {
+		loadedScale, scaleError := loadPriorityScale(*scalePathFlag)
+		if scaleError != nil {
+			fmt.Println("FATAL:", scaleError)
+			return
+		}
+		scale = loadedScale
+	}
+
+	wantData, wantError := loadWantFile(*wantPathFlag, scale)
+	if wantError != nil {
+		fmt.Println("FATAL:", wantError)
+		return
+	}
+
+	if writeError := writeWantFile(*outputPathFlag, wantData); writeError != nil {
+		fmt.Println("FATAL:", writeError)
+		return
+	}
+	fmt.Println(outputWrittenPrefixLiteral, *outputPathFlag)
+}
+
+// writeWantFile saves want as structured JSON or, for any other extension, as want.csv.
+func writeWantFile(wantPath string, want planner.Preferences) error {
+	if !strings.EqualFold(filepath.Ext(wantPath), structuredWantExtensionLiteral) {
+		return writeWantCSV(wantPath, want)
+	}
+	fileHandle, createError := os.Create(wantPath)
+	if createError != nil {
+		return createError
+	}
+	defer fileHandle.Close()
+
+	jsonEncoder := json.NewEncoder(fileHandle)
+	jsonEncoder.SetEscapeHTML(false)
+	jsonEncoder.SetIndent(emptyLiteral, "  ")
+	return jsonEncoder.Encode(toStructured(want))
+}
+
+func toStructuredWindow(window planner.WeeklyWindow) structuredWindow {
+	return structuredWindow{
+		Days:  planner.FormatWeekdays(window.Weekdays),
+		Start: planner.MinutesToMilitaryTime(window.StartClockMinutes),
+		End:   planner.MinutesToMilitaryTime(window.EndClockMinutes),
+		From:  window.FromDate.Format(dateLayoutISOLiteral),
+		To:    window.ToDate.Format(dateLayoutISOLiteral),
+	}
+}
+
+// writeWantCSV saves ages and priorities in the want.csv layout. Settings the
+// CSV cannot hold are an error rather than being dropped silently.
+func writeWantCSV(wantCSVPath string, want planner.Preferences) error {
+	headerRow := []string{wantCampColumnLiteral}
+	if want.Budget > 0 {
+		return fmt.Errorf("%s: want.csv cannot hold a budget; use a %s want file", wantCSVPath, structuredWantExtensionLiteral)
+	}
+	for _, child := range want.Children {
+		if !child.Birthdate.IsZero() || child.Grade != emptyLiteral || hasChildLimits(child) || len(child.Blackouts) > 0 || len(child.Coverage) > 0 || len(child.Commitments) > 0 || len(child.Friends) > 0 {
+			return fmt.Errorf("%s: %s has settings want.csv cannot hold; use a %s want file", wantCSVPath, child.Name, structuredWantExtensionLiteral)
+		}
+		headerRow = append(headerRow, child.Name+wantAgeColumnSuffixLiteral, child.Name+wantPriorityColumnSuffixLiteral)
+	}
+
+	fileHandle, createError := os.Create(wantCSVPath)
+	if createError != nil {
+		return createError
+	}
+	defer fileHandle.Close()
+
+	csvWriter := csv.NewWriter(fileHandle)
+	_ = csvWriter.Write(headerRow)
+	for rowIndex, title := range want.Titles() {
+		row := []string{title}
+		for _, child := range want.Children {
+			ageText := emptyLiteral
+			if rowIndex == 0 {
+				ageText = strconv.Itoa(child.Age)
+			}
+			row = append(row, ageText, want.Priorities[title][child.Name])
+		}
+		_ = csvWriter.Write(row)
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
@@ -0,0 +1,174 @@
+// cmd/schedule/want_test.go
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"SummerCamp25/planner"
+)
+
+const structuredWantTestJSON = `{
+  "blackouts": [{"from": "2025-07-04", "to": "2025-07-04", "note": "Holiday"}],
+  "children": [
+    {
+      "name": "Bob",
+      "age": 6,
+      "friends": ["Zoe"],
+      "preferences": {"Swim": "Low"}
+    },
+    {
+      "name": "Alice",
+      "birthdate": "2017-07-01",
+      "grade": "3",
+      "maxSessionsPerWeek": 2,
+      "blackouts": [{"from": "2025-08-01", "to": "2025-08-08"}],
+      "coverage": [{"days": "Mon-Fri", "start": "09:00", "end": "15:00", "from": "2025-06-16", "to": "2025-08-22"}],
+      "preferences": {"Art": "High", "Swim": "must"}
+    }
+  ]
+}`
+
+func TestLoadStructuredWantFile(t *testing.T) {
+	want, loadError := loadWantFile(writeTestFile(t, "want.json", structuredWantTestJSON), planner.DefaultPriorityScale())
+	if loadError != nil {
+		t.Fatal(loadError)
+	}
+	if !slices.Equal(want.ChildNames(), []string{"Alice", "Bob"}) {
+		t.Errorf("children = %q, want Alice and Bob", want.ChildNames())
+	}
+	if bob, _ := want.ChildNamed("Bob"); bob.Age != 6 {
+		t.Errorf("Bob's age = %d, want 6", bob.Age)
+	}
+	if want.Priorities["Art"]["Alice"] != planner.PriorityHigh || want.Priorities["Swim"]["Bob"] != planner.PriorityLow {
+		t.Errorf("priorities = %v", want.Priorities)
+	}
+
+	alice, _ := want.ChildNamed("Alice")
+	if alice.Grade != "3" || alice.MaxSessionsPerWeek != 2 {
+		t.Errorf("Alice = %+v, want grade 3 and two sessions a week", alice)
+	}
+	if len(alice.Blackouts) != 2 || alice.Blackouts[0].Note != "Holiday" {
+		t.Errorf("Alice's blackouts = %+v, want the family holiday then her own week", alice.Blackouts)
+	}
+	if len(alice.Coverage) != 1 || alice.Coverage[0].StartClockMinutes != planner.MilitaryTimeToMinutes("09:00") {
+		t.Errorf("Alice's coverage = %+v, want one 09:00-15:00 window", alice.Coverage)
+	}
+	if bob, _ := want.ChildNamed("Bob"); len(bob.Blackouts) != 1 {
+		t.Errorf("Bob's blackouts = %+v, want only the family holiday", bob.Blackouts)
+	}
+	if bob, _ := want.ChildNamed("Bob"); !slices.Equal(bob.Friends, []string{"Zoe"}) {
+		t.Errorf("Bob's friends = %q, want Zoe", bob.Friends)
+	}
+}
+
+func TestWriteWantFileKeepsFriends(t *testing.T) {
+	want := testPreferences(map[string]map[string]string{"Art": {"Alice": planner.PriorityHigh}}, "Alice")
+	want.Children[0].Friends = []string{"Zoe"}
+
+	outputDirectory := t.TempDir()
+	jsonPath := filepath.Join(outputDirectory, "want.json")
+	if writeError := writeWantFile(jsonPath, want); writeError != nil {
+		t.Fatal(writeError)
+	}
+	reloaded, loadError := loadWantFile(jsonPath, planner.DefaultPriorityScale())
+	if loadError != nil {
+		t.Fatal(loadError)
+	}
+	if alice, _ := reloaded.ChildNamed("Alice"); !slices.Equal(alice.Friends, []string{"Zoe"}) {
+		t.Errorf("reloaded friends = %q, want Zoe", alice.Friends)
+	}
+
+	if writeError := writeWantFile(filepath.Join(outputDirectory, "want.csv"), want); writeError == nil || !strings.Contains(writeError.Error(), "Alice has settings want.csv cannot hold") {
+		t.Errorf("error = %v, want one refusing Alice's friends", writeError)
+	}
+}
+
+func TestLoadStructuredWantFileReportsProblems(t *testing.T) {
+	problemJSON := `{"children": [
+		{"name": "Alice", "age": 8, "preferences": {"Art": "Hihg"}},
+		{"name": "Alice", "age": 8},
+		{"age": 6},
+		{"name": "Bob", "birthdate": "June 2019"},
+		{"name": "Cara"},
+		{"name": "Dan", "age": 7, "coverage": [{"days": "Mon", "start": "15:00", "end": "09:00", "from": "2025-06-16", "to": "2025-06-20"}]}
+	]}`
+	_, loadError := loadWantFile(writeTestFile(t, "want.json", problemJSON), planner.DefaultPriorityScale())
+	if loadError == nil {
+		t.Fatal("want file with problems loaded")
+	}
+	for _, want := range []string{
+		`children[0].preferences["Art"]: unknown priority "Hihg"`,
+		`children[1]: duplicate child "Alice"`,
+		`children[2]: missing name`,
+		`children[3]: invalid birthdate "June 2019"`,
+		`children[4]: Cara needs a birthdate or an age`,
+		`children[5].coverage[0]: `,
+	} {
+		if !strings.Contains(loadError.Error(), want) {
+			t.Errorf("error %q does not report %s", loadError, want)
+		}
+	}
+}
+
+func TestChildAgeOn(t *testing.T) {
+	want, loadError := loadWantFile(writeTestFile(t, "want.json", structuredWantTestJSON), planner.DefaultPriorityScale())
+	if loadError != nil {
+		t.Fatal(loadError)
+	}
+	tests := []struct {
+		childName string
+		date      string
+		wantAge   int
+	}{
+		{childName: "Alice", date: "2025-06-30", wantAge: 7},
+		{childName: "Alice", date: "2025-07-01", wantAge: 8},
+		{childName: "Bob", date: "2025-08-01", wantAge: 6},
+	}
+	for _, test := range tests {
+		date, _ := time.Parse(dateLayoutISOLiteral, test.date)
+		child, _ := want.ChildNamed(test.childName)
+		if age := child.AgeOn(date); age != test.wantAge {
+			t.Errorf("%s's age on %s = %d, want %d", test.childName, test.date, age, test.wantAge)
+		}
+	}
+}
+
+func TestConvertRoundTrip(t *testing.T) {
+	wantCSV := "Activity,Alice's Age,Alice's Priority,Bob's Age,Bob's Priority\n" +
+		"Art,8,High,6,\n" +
+		"Swim,8,Must,6,Low\n"
+	csvPath := writeTestFile(t, "want.csv", wantCSV)
+	fromCSV, loadError := loadWantFile(csvPath, planner.DefaultPriorityScale())
+	if loadError != nil {
+		t.Fatal(loadError)
+	}
+
+	outputDirectory := t.TempDir()
+	jsonPath := filepath.Join(outputDirectory, "want.json")
+	runConvert([]string{"-" + flagWantParameterNameLiteral, csvPath, "-" + flagOutputParameterNameLiteral, jsonPath})
+	fromJSON, loadError := loadWantFile(jsonPath, planner.DefaultPriorityScale())
+	if loadError != nil {
+		t.Fatal(loadError)
+	}
+	if !reflect.DeepEqual(fromJSON.Children, fromCSV.Children) {
+		t.Errorf("converted children = %+v, want %+v", fromJSON.Children, fromCSV.Children)
+	}
+	if !reflect.DeepEqual(fromJSON.Priorities, fromCSV.Priorities) {
+		t.Errorf("converted priorities = %v, want %v", fromJSON.Priorities, fromCSV.Priorities)
+	}
+
+	// Converting the JSON again reproduces it byte for byte.
+	againPath := filepath.Join(outputDirectory, "again.json")
+	runConvert([]string{"-" + flagWantParameterNameLiteral, jsonPath, "-" + flagOutputParameterNameLiteral, againPath})
+	first, _ := os.ReadFile(jsonPath)
+	again, _ := os.ReadFile(againPath)
+	if string(first) != string(again) {
+		t.Errorf("second conversion differs:\n%s\nwant\n%s", again, first)
+	}
+}
@@ -32,19 +32,27 @@ const (
 	dateRangeSelector        = `.activity-card-info__dateRange > span`
 	timeRangeSelector        = `.activity-card-info__timeRange > span`
 	ageSelector              = `.activity-card-info__ages`
+	locationSelector         = `.activity-card-info__location`
+	priceSelector            = `.activity-card-info__fee`
+	numberSelector           = `.activity-card-info__number`
+	titleLinkSelector        = `.activity-card-info__name a, a.activity-card-info__name`
+	registrationLayout       = "2006-01-02T15:04"
 	cornerMarkSelector       = `.activity-card__cornerMark`
 	alertTextSelector        = `.activity-card-alert__text`
 	bodySelector             = `body`
 	ageRegexPattern          = `at least (\d+) yrs but less than (\d+) yrs`
 	defaultAvailability      = "Available"
 	flagCSVParameterName     = "csv"
-	flagCSVParameterUsage    = "path to CSV file with a Camp column"
+	flagCSVParameterUsage    = "path to CSV file with a Camp column and an optional Search column"
 	flagOutputParameterName  = "out"
 	flagOutputParameterUsage = "path to file for the combined JSON output"
 	baseSearchURL            = "https://anc.apm.activecommunities.com/citymb/activity/search?onlineSiteId=0&activity_select_param=2&viewMode=list&activity_keyword=%s"
 )
 
 var ageRegex = regexp.MustCompile(ageRegexPattern)
+var ageSuffixRegex = regexp.MustCompile(`(?i)\s*\([^)]*\d[^)]*\)\s*$`)
+var activityNumberRegex = regexp.MustCompile(`#\s*(\d{3,})`)
+var registrationOpensRegex = regexp.MustCompile(`(?i)(?:registration|enrollment)\s+(?:opens|begins|starts)\s+(?:on\s+)?([A-Z][a-z]+\.? \d{1,2}, \d{4}|\d{1,2}/\d{1,2}/\d{4})(?:,?\s+(?:at\s+)?(\d{1,2}(?::\d{2})?\s*[AP]M))?`)
 var weekdayIndex = map[string]int{"Mon": 0, "Tue": 1, "Wed": 2, "Thu": 3, "Fri": 4, "Sat": 5, "Sun": 6}
 
 type Session struct {
@@ -58,6 +66,13 @@ type Session struct {
 	MaxAge        *int     `json:"maxAge,omitempty"`
 	Availability  string   `json:"availability"`
 	PageURL       string   `json:"pageUrl"`
+	Location      string   `json:"location,omitempty"`
+	Price         string   `json:"price,omitempty"`
+	// ActivityNumber is the site's "#12345" code; DetailURL links straight to the session.
+	ActivityNumber string `json:"activityNumber,omitempty"`
+	DetailURL      string `json:"detailUrl,omitempty"`
+	// RegistrationOpens is the local wall-clock moment registration opens, as 2006-01-02T15:04.
+	RegistrationOpens string `json:"registrationOpens,omitempty"`
 }
 
 func main() {
@@ -127,11 +142,13 @@ func loadCampNames(path string) ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
-	idx := -1
+	idx, searchIdx := -1, -1
 	for i, n := range header {
-		if n == "Camp" {
+		switch n {
+		case "Camp":
 			idx = i
-			break
+		case "Search":
+			searchIdx = i
 		}
 	}
 	if idx < 0 {
@@ -146,8 +163,14 @@ func loadCampNames(path string) ([]string, error) {
 			}
 			return nil, err
 		}
+		if searchIdx >= 0 && searchIdx < len(row) {
+			if v := strings.TrimSpace(row[searchIdx]); v != "" {
+				names = append(names, v)
+				continue
+			}
+		}
 		if idx < len(row) {
-			if v := strings.TrimSpace(row[idx]); v != "" {
+			if v := searchKeyword(row[idx]); v != "" {
 				names = append(names, v)
 			}
 		}
@@ -155,6 +178,12 @@ func loadCampNames(path string) ([]string, error) {
 	return names, nil
 }
 
+// searchKeyword drops a trailing "(Ages 6-9)"-style suffix so the search also
+// finds sessions whose listed age range has drifted.
+func searchKeyword(campName string) string {
+	return strings.TrimSpace(ageSuffixRegex.ReplaceAllString(campName, ""))
+}
+
 func mustParse(layout, value string) time.Time {
 	t, err := time.Parse(layout, value)
 	if err != nil {
@@ -215,6 +244,47 @@ func expandDays(token string) []string {
 	return out
 }
 
+// parseRegistrationOpens finds "Registration opens on March 1, 2025 at 9:00 AM"
+// (or 03/01/2025) in a card's text. A missing time means midnight.
+func parseRegistrationOpens(text string) string {
+	m := registrationOpensRegex.FindStringSubmatch(text)
+	if m == nil {
+		return ""
+	}
+	var day time.Time
+	var err error
+	for _, layout := range []string{"January 2, 2006", "Jan. 2, 2006", "Jan 2, 2006", "1/2/2006"} {
+		if day, err = time.Parse(layout, m[1]); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return ""
+	}
+	minutes := 0
+	if m[2] != "" {
+		minutes = clockMinutes(m[2])
+	}
+	return day.Add(time.Duration(minutes) * time.Minute).Format(registrationLayout)
+}
+
+// detailURL resolves the card's title link against the search page.
+func detailURL(pageURL string, s *goquery.Selection) string {
+	href, ok := s.Find(titleLinkSelector).First().Attr("href")
+	if !ok || href == "" {
+		return ""
+	}
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return ""
+	}
+	link, err := base.Parse(href)
+	if err != nil {
+		return ""
+	}
+	return link.String()
+}
+
 func parseDateRange(r string) (time.Time, time.Time) {
 	if strings.Contains(r, "to") {
 		parts := strings.Split(r, "to")
@@ -295,6 +365,14 @@ func scrapePage(parent context.Context, pageURL string) ([]Session, error) {
 		dateText := strings.TrimSpace(s.Find(dateRangeSelector).Text())
 		timeText := strings.TrimSpace(s.Find(timeRangeSelector).Text())
 		ageText := strings.TrimSpace(s.Find(ageSelector).Text())
+		locationText := strings.TrimSpace(s.Find(locationSelector).Text())
+		priceText := strings.TrimSpace(s.Find(priceSelector).Text())
+		activityNumber := ""
+		if m := activityNumberRegex.FindStringSubmatch(s.Find(numberSelector).Text()); m != nil {
+			activityNumber = m[1]
+		} else if m := activityNumberRegex.FindStringSubmatch(s.Text()); m != nil {
+			activityNumber = m[1]
+		}
 		var minPtr, maxPtr *int
 		if m := ageRegex.FindStringSubmatch(ageText); len(m) == 3 {
 			if v, err := strconv.Atoi(m[1]); err == nil {
@@ -313,16 +391,21 @@ func scrapePage(parent context.Context, pageURL string) ([]Session, error) {
 		ds, de := parseDateRange(dateText)
 		startM, endM, days := splitTimeRange(timeText)
 		list = append(list, Session{
-			Title:         title,
-			StartDateUnix: ds.Unix(),
-			EndDateUnix:   de.Unix(),
-			Days:          days,
-			StartMinutes:  startM,
-			EndMinutes:    endM,
-			MinAge:        minPtr,
-			MaxAge:        maxPtr,
-			Availability:  availability,
-			PageURL:       pageURL,
+			Title:             title,
+			StartDateUnix:     ds.Unix(),
+			EndDateUnix:       de.Unix(),
+			Days:              days,
+			StartMinutes:      startM,
+			EndMinutes:        endM,
+			MinAge:            minPtr,
+			MaxAge:            maxPtr,
+			Availability:      availability,
+			PageURL:           pageURL,
+			Location:          locationText,
+			Price:             priceText,
+			ActivityNumber:    activityNumber,
+			DetailURL:         detailURL(pageURL, s),
+			RegistrationOpens: parseRegistrationOpens(s.Text()),
 		})
 	})
 	return list, nil
@@ -0,0 +1,123 @@
+// planner/availability.go
+package planner
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// AvailabilityState classifies a session's scraped availability text.
+type AvailabilityState string
+
+const (
+	AvailabilityOpen         AvailabilityState = "open"
+	AvailabilityFewLeft      AvailabilityState = "few-left"
+	AvailabilityStartingSoon AvailabilityState = "starting-soon"
+	AvailabilityWaitlist     AvailabilityState = "waitlist"
+	AvailabilityFull         AvailabilityState = "full"
+	AvailabilityUnknown      AvailabilityState = "unknown"
+	availabilityWaitlistWord                   = "waitlist"
+	availabilityWaitingWord                    = "waiting list"
+	availabilityFullWord                       = "full"
+	availabilityClosedWord                     = "closed"
+)
+
+// AvailabilityStates lists every state, most available first.
+var AvailabilityStates = []AvailabilityState{
+	AvailabilityOpen, AvailabilityStartingSoon, AvailabilityFewLeft, AvailabilityWaitlist, AvailabilityFull, AvailabilityUnknown,
+}
+
+// DefaultSuccessProbabilities guesses the chance that registering succeeds in each state.
+func DefaultSuccessProbabilities() map[AvailabilityState]float64 {
+	return map[AvailabilityState]float64{
+		AvailabilityOpen:         0.95,
+		AvailabilityStartingSoon: 0.9,
+		AvailabilityFewLeft:      0.6,
+		AvailabilityWaitlist:     0.15,
+		AvailabilityFull:         0,
+		AvailabilityUnknown:      0,
+	}
+}
+
+// AvailabilityStatus is the parsed availability text: its state and, when
+// the text gives one ("3 spaces left"), how many spots remain.
+type AvailabilityStatus struct {
+	State      AvailabilityState
+	SpotsLeft  int
+	SpotsKnown bool
+}
+
+// Status parses the session's availability text.
+func (session Session) Status() AvailabilityStatus {
+	spots, known := session.SpotsLeft()
+	return AvailabilityStatus{State: session.Availability(), SpotsLeft: spots, SpotsKnown: known}
+}
+
+// SpotsLeft reads "3 spaces left" from the availability text.
+func (session Session) SpotsLeft() (int, bool) {
+	match := spacesLeftPattern.FindStringSubmatch(session.AvailabilityText)
+	if match == nil {
+		return 0, false
+	}
+	count, _ := strconv.Atoi(match[1])
+	return count, true
+}
+
+// hasRoomFor reports whether attendees children fit the spots left; sessions
+// without a count have room for everyone.
+func (session Session) hasRoomFor(attendees int) bool {
+	spots, known := session.SpotsLeft()
+	return !known || attendees <= spots
+}
+
+// Availability classifies the session's availability text.
+func (session Session) Availability() AvailabilityState {
+	lower := strings.TrimSpace(strings.ToLower(session.AvailabilityText))
+	switch {
+	case lower == emptyLiteral || lower == availabilityAvailableLiteral:
+		return AvailabilityOpen
+	case lower == availabilityStartingSoonLiteral:
+		return AvailabilityStartingSoon
+	case strings.Contains(lower, availabilitySpaceLeftIdentifierLiteral) && strings.Contains(lower, availabilityLeftIdentifierLiteral):
+		return AvailabilityFewLeft
+	case strings.Contains(lower, availabilityWaitlistWord) || strings.Contains(lower, availabilityWaitingWord):
+		return AvailabilityWaitlist
+	case strings.Contains(lower, availabilityFullWord) || strings.Contains(lower, availabilityClosedWord):
+		return AvailabilityFull
+	default:
+		return AvailabilityUnknown
+	}
+}
+
+// IsOpen reports whether the availability text still allows registering.
+func (session Session) IsOpen() bool {
+	switch session.Availability() {
+	case AvailabilityOpen, AvailabilityStartingSoon, AvailabilityFewLeft:
+		return true
+	default:
+		return false
+	}
+}
+
+// SuccessProbability is the chance that registering for the session succeeds.
+// Without a probability table every open session is certain and every other one impossible.
+func (options Options) SuccessProbability(session Session) float64 {
+	if options.SuccessProbabilities == nil {
+		if session.IsOpen() {
+			return 1
+		}
+		return 0
+	}
+	return options.SuccessProbabilities[session.Availability()]
+}
+
+// successPercent is SuccessProbability in whole percent, so candidate gains stay integers.
+func (options Options) successPercent(session Session) int {
+	return int(math.Round(options.SuccessProbability(session) * 100))
+}
+
+// eligible reports whether the planner may pick the session at all.
+func (options Options) eligible(session Session) bool {
+	return options.successPercent(session) > 0
+}
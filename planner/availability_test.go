@@ -0,0 +1,85 @@
+// planner/availability_test.go
+package planner
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestSessionAvailability(t *testing.T) {
+	tests := []struct {
+		text string
+		want AvailabilityState
+	}{
+		{text: "", want: AvailabilityOpen},
+		{text: "Available", want: AvailabilityOpen},
+		{text: "Starting Soon", want: AvailabilityStartingSoon},
+		{text: "3 spaces left", want: AvailabilityFewLeft},
+		{text: "Waitlist", want: AvailabilityWaitlist},
+		{text: "Join the waiting list", want: AvailabilityWaitlist},
+		{text: "Full", want: AvailabilityFull},
+		{text: "Registration closed", want: AvailabilityFull},
+		{text: "Call the office", want: AvailabilityUnknown},
+	}
+	for _, test := range tests {
+		if got := (Session{AvailabilityText: test.text}).Availability(); got != test.want {
+			t.Errorf("Availability(%q) = %s, want %s", test.text, got, test.want)
+		}
+	}
+}
+
+func TestPlanExpectedValue(t *testing.T) {
+	// Art is worth more but has few spaces left; Swim clashes with it and is
+	// open. Drama, a week later, is only on the waitlist.
+	art := testSession("Art", "2025-06-16", "09:00", "12:00")
+	art.AvailabilityText = "3 spaces left"
+	swim := testSession("Swim", "2025-06-16", "09:00", "12:00")
+	drama := testSession("Drama", "2025-06-23", "09:00", "12:00")
+	drama.AvailabilityText = "Waitlist"
+	sessions := []Session{art, swim, drama}
+	preferences := testPreferences(map[string]map[string]string{
+		"Art":   {"Alice": PriorityHigh},
+		"Swim":  {"Alice": PriorityMedium},
+		"Drama": {"Alice": PriorityLow},
+	}, "Alice")
+
+	tests := []struct {
+		name          string
+		probabilities map[AvailabilityState]float64
+		want          []string
+		wantScore     int
+		wantExpected  float64
+	}{
+		{
+			name:         "without probabilities open sessions are certain",
+			want:         []string{"Art 2025-06-16"},
+			wantScore:    3,
+			wantExpected: 3,
+		},
+		{
+			name:          "expected value prefers the likelier session and tries the waitlist",
+			probabilities: DefaultSuccessProbabilities(),
+			want:          []string{"Swim 2025-06-16", "Drama 2025-06-23"},
+			wantScore:     3,
+			wantExpected:  2*0.95 + 1*0.15,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := DefaultOptions()
+			options.SuccessProbabilities = test.probabilities
+			result, planError := New(options).Plan(context.Background(), sessions, preferences)
+			if planError != nil {
+				t.Fatal(planError)
+			}
+			if got := plannedSessions(result)["Alice"]; !slices.Equal(got, test.want) {
+				t.Errorf("plan = %q, want %q", got, test.want)
+			}
+			if result.Score != test.wantScore || math.Abs(result.ExpectedScore-test.wantExpected) > 1e-9 {
+				t.Errorf("score = %d, expected %.2f; want %d, expected %.2f", result.Score, result.ExpectedScore, test.wantScore, test.wantExpected)
+			}
+		})
+	}
+}
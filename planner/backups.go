@@ -0,0 +1,143 @@
+// planner/backups.go
+package planner
+
+import (
+	"sort"
+	"time"
+)
+
+// DefaultBackups is how many fallbacks are kept per chosen session.
+const DefaultBackups = 3
+
+// computeBackups ranks, for every chosen session of every child, the sessions
+// that would fit the rest of that child's plan if this one filled first:
+// sessions of the same activity first, then by expected priority, covered
+// minutes and closeness of the start date to the original. Sessions whose
+// spots are taken by siblings are skipped unless overbooking is allowed, and
+// so are sessions the household budget cannot pay for in place of the chosen one.
+func computeBackups(plans map[string]*Plan, sessions []Session, preferences Preferences, options Options) {
+	if options.Backups <= 0 {
+		return
+	}
+	attendeesBySession := map[string]int{}
+	for _, plan := range plans {
+		for _, session := range plan.Sessions {
+			attendeesBySession[session.Key()]++
+		}
+	}
+	households := preferences.households()
+	householdByChild := householdIndex(households)
+	for childName, plan := range plans {
+		plan.Backups = map[string][]Session{}
+		household := households[householdByChild[childName]]
+		for _, chosen := range plan.Sessions {
+			trial := plan.without(chosen)
+			plansWithout := householdPlans(plans, household)
+			plansWithout[childName] = trial
+			chosenCost, _ := chosen.Cost()
+			spentWithout := spent(plans, household) - chosenCost
+
+			var fallbacks []Session
+			for _, candidate := range sessions {
+				if candidate.Key() == chosen.Key() || !options.eligible(candidate) || !preferences.Wants(childName, candidate) {
+					continue
+				}
+				if !options.OverbookSpots && !candidate.hasRoomFor(attendeesBySession[candidate.Key()]+1) {
+					continue
+				}
+				if !trial.fits(candidate) || !options.constraintsAllow(childName, trial, candidate) {
+					continue
+				}
+				if !household.withinBudget(spentWithout, candidate, 1) {
+					continue
+				}
+				if drivable, _ := driverRunIsFeasible(plansWithout, candidate, []string{childName}, options); !drivable {
+					continue
+				}
+				fallbacks = append(fallbacks, candidate)
+			}
+
+			sort.SliceStable(fallbacks, func(i, j int) bool {
+				return backupRanksBefore(fallbacks[i], fallbacks[j], chosen, childName, plan.Child, preferences, options)
+			})
+			if len(fallbacks) > options.Backups {
+				fallbacks = fallbacks[:options.Backups]
+			}
+			if len(fallbacks) > 0 {
+				plan.Backups[chosen.Key()] = fallbacks
+			}
+		}
+	}
+}
+
+func backupRanksBefore(first, second, chosen Session, childName string, child Child, preferences Preferences, options Options) bool {
+	firstSame, secondSame := first.ActivityKey() == chosen.ActivityKey(), second.ActivityKey() == chosen.ActivityKey()
+	if firstSame != secondSame {
+		return firstSame
+	}
+	if firstScore, secondScore := preferences.ScoreOf(childName, first)*options.successPercent(first), preferences.ScoreOf(childName, second)*options.successPercent(second); firstScore != secondScore {
+		return firstScore > secondScore
+	}
+	if firstCovered, secondCovered := CoveredMinutes(first, child.Coverage), CoveredMinutes(second, child.Coverage); firstCovered != secondCovered {
+		return firstCovered > secondCovered
+	}
+	if firstDistance, secondDistance := absoluteDuration(first.StartDate().Sub(chosen.StartDate())), absoluteDuration(second.StartDate().Sub(chosen.StartDate())); firstDistance != secondDistance {
+		return firstDistance < secondDistance
+	}
+	return first.StartDate().Before(second.StartDate())
+}
+
+func absoluteDuration(duration time.Duration) time.Duration {
+	if duration < 0 {
+		return -duration
+	}
+	return duration
+}
+
+// without returns a copy of the plan minus one session.
+func (plan *Plan) without(removed Session) *Plan {
+	trial := &Plan{
+		Child:                 plan.Child,
+		MissedDates:           map[string][]time.Time{},
+		enrolledActivitiesSet: map[string]struct{}{},
+		bufferMinutes:         plan.bufferMinutes,
+		blackoutToleranceDays: plan.blackoutToleranceDays,
+		summerWeeks:           plan.summerWeeks,
+		rules:                 plan.rules,
+	}
+	for _, session := range plan.Sessions {
+		if session.Key() != removed.Key() {
+			trial.add(session)
+		}
+	}
+	return trial
+}
+
+// BackupsFor lists the fallbacks of a chosen session that work for every one
+// of childNames, in the first child's order, with spots left for all of them.
+func (result *Result) BackupsFor(session Session, childNames []string) []Session {
+	if len(childNames) == 0 {
+		return nil
+	}
+	var shared []Session
+	for _, backup := range result.Plans[childNames[0]].Backups[session.Key()] {
+		everyChild := true
+		for _, childName := range childNames[1:] {
+			found := false
+			for _, other := range result.Plans[childName].Backups[session.Key()] {
+				if other.Key() == backup.Key() {
+					found = true
+					break
+				}
+			}
+			if !found {
+				everyChild = false
+				break
+			}
+		}
+		if everyChild && (result.options.OverbookSpots || backup.hasRoomFor(result.SpotsTaken(backup)+len(childNames))) {
+			shared = append(shared, backup)
+		}
+	}
+	return shared
+}
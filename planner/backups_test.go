@@ -0,0 +1,82 @@
+// planner/backups_test.go
+package planner
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// planOf is a plan for child already holding sessions, with default options.
+func planOf(child Child, sessions ...Session) *Plan {
+	plan := &Plan{
+		Child:                 child,
+		MissedDates:           map[string][]time.Time{},
+		enrolledActivitiesSet: map[string]struct{}{},
+		bufferMinutes:         DefaultBufferMinutes,
+	}
+	for _, session := range sessions {
+		plan.add(session)
+	}
+	return plan
+}
+
+// backupTitles lists the sessions as "Title 2025-06-16".
+func backupTitles(sessions []Session) []string {
+	var titles []string
+	for _, session := range sessions {
+		titles = append(titles, session.ActivityName+" "+session.StartDate().UTC().Format(dateLayoutISOLiteral))
+	}
+	return titles
+}
+
+func TestComputeBackups(t *testing.T) {
+	art := testSession("Art", "2025-06-16", "09:00", "12:00")
+	swim := testSession("Swim", "2025-06-23", "13:00", "15:00")
+	full := testSession("Art", "2025-06-30", "09:00", "12:00")
+	full.AvailabilityText = "Full"
+	sessions := []Session{
+		art,
+		swim,
+		testSession("Chess", "2025-06-16", "09:00", "12:00"),
+		testSession("Drama", "2025-07-14", "09:00", "12:00"),
+		testSession("Drama", "2025-06-16", "13:00", "15:00"),
+		testSession("Drama", "2025-06-23", "13:00", "15:00"),
+		testSession("Swim", "2025-06-16", "13:00", "15:00"),
+		testSession("Art", "2025-07-07", "09:00", "12:00"),
+		full,
+	}
+	preferences := testPreferences(map[string]map[string]string{
+		"Art":   {"Alice": PriorityMust},
+		"Swim":  {"Alice": PriorityHigh},
+		"Drama": {"Alice": PriorityHigh},
+		"Chess": {"Alice": PriorityLow},
+	}, "Alice")
+	plans := map[string]*Plan{"Alice": planOf(preferences.Children[0], art, swim)}
+	computeBackups(plans, sessions, preferences, DefaultOptions())
+
+	// Another Art session comes first, then the higher priority, then the
+	// start nearest the original. The full Art session, the second Swim and
+	// the Drama clashing with the planned Swim never qualify.
+	want := []string{"Art 2025-07-07", "Drama 2025-06-16", "Drama 2025-07-14"}
+	if got := backupTitles(plans["Alice"].Backups[art.Key()]); !slices.Equal(got, want) {
+		t.Errorf("backups for Art = %q, want %q", got, want)
+	}
+}
+
+func TestBackupsFor(t *testing.T) {
+	art := testSession("Art", "2025-06-16", "09:00", "12:00")
+	artLater := testSession("Art", "2025-06-23", "09:00", "12:00")
+	chess := testSession("Chess", "2025-06-16", "09:00", "12:00")
+	drama := testSession("Drama", "2025-06-16", "09:00", "12:00")
+	result := &Result{Plans: map[string]*Plan{
+		"Alice": {Backups: map[string][]Session{art.Key(): {artLater, chess, drama}}},
+		"Bob":   {Backups: map[string][]Session{art.Key(): {drama, artLater}}},
+	}}
+	if got, want := backupTitles(result.BackupsFor(art, []string{"Alice", "Bob"})), []string{"Art 2025-06-23", "Drama 2025-06-16"}; !slices.Equal(got, want) {
+		t.Errorf("shared backups = %q, want %q", got, want)
+	}
+	if got := result.BackupsFor(art, nil); got != nil {
+		t.Errorf("backups for no children = %q, want none", backupTitles(got))
+	}
+}
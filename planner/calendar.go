@@ -0,0 +1,217 @@
+// planner/calendar.go
+package planner
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	weekdayRangeSeparator = "-"
+	// WeekdayListSeparator joins weekdays in "Mon,Wed,Fri".
+	WeekdayListSeparator = ","
+)
+
+// WeekdayAbbreviations are the weekday names sessions use, Monday first.
+var WeekdayAbbreviations = []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+// WeeklyWindow is a clock-minute window repeating on a set of weekdays within a date range.
+type WeeklyWindow struct {
+	Weekdays          map[string]struct{}
+	StartClockMinutes int
+	EndClockMinutes   int
+	FromDate          time.Time
+	ToDate            time.Time
+}
+
+// Blackout is an inclusive span of calendar dates a child is away.
+type Blackout struct {
+	FromDate time.Time
+	ToDate   time.Time
+	Note     string
+}
+
+// AppliesOn reports whether the window is in effect on the given calendar date.
+func (window WeeklyWindow) AppliesOn(date time.Time) bool {
+	if date.Before(window.FromDate) || date.After(window.ToDate) {
+		return false
+	}
+	_, onWeekday := window.Weekdays[WeekdayAbbreviation(date)]
+	return onWeekday
+}
+
+// ParseWeeklyWindow builds a WeeklyWindow from its textual parts.
+func ParseWeeklyWindow(daysText, startText, endText, fromText, toText string) (WeeklyWindow, error) {
+	weekdays, weekdaysError := ExpandWeekdays(daysText)
+	if weekdaysError != nil {
+		return WeeklyWindow{}, weekdaysError
+	}
+	fromDate, fromError := time.Parse(dateLayoutISOLiteral, fromText)
+	if fromError != nil {
+		return WeeklyWindow{}, fmt.Errorf("invalid from date %q", fromText)
+	}
+	toDate, toError := time.Parse(dateLayoutISOLiteral, toText)
+	if toError != nil {
+		return WeeklyWindow{}, fmt.Errorf("invalid to date %q", toText)
+	}
+	startMinutes := MilitaryTimeToMinutes(startText)
+	endMinutes := MilitaryTimeToMinutes(endText)
+	if endMinutes <= startMinutes {
+		return WeeklyWindow{}, fmt.Errorf("window %s-%s ends before it starts", startText, endText)
+	}
+	return WeeklyWindow{
+		Weekdays:          weekdays,
+		StartClockMinutes: startMinutes,
+		EndClockMinutes:   endMinutes,
+		FromDate:          fromDate,
+		ToDate:            toDate,
+	}, nil
+}
+
+// ExpandWeekdays turns "Mon-Fri" or "Mon,Wed,Fri" into a weekday set.
+func ExpandWeekdays(daysText string) (map[string]struct{}, error) {
+	weekdays := map[string]struct{}{}
+	for _, segment := range strings.Split(daysText, WeekdayListSeparator) {
+		segment = strings.TrimSpace(segment)
+		if segment == emptyLiteral {
+			continue
+		}
+		bounds := strings.Split(segment, weekdayRangeSeparator)
+		startIndex := weekdayIndexOf(strings.TrimSpace(bounds[0]))
+		endIndex := weekdayIndexOf(strings.TrimSpace(bounds[len(bounds)-1]))
+		if len(bounds) > 2 || startIndex < 0 || endIndex < 0 {
+			return nil, fmt.Errorf("invalid days %q", daysText)
+		}
+		for offset := 0; ; offset++ {
+			index := (startIndex + offset) % len(WeekdayAbbreviations)
+			weekdays[WeekdayAbbreviations[index]] = struct{}{}
+			if index == endIndex {
+				break
+			}
+		}
+	}
+	if len(weekdays) == 0 {
+		return nil, fmt.Errorf("no days in %q", daysText)
+	}
+	return weekdays, nil
+}
+
+// FormatWeekdays lists a weekday set in week order, e.g. "Mon,Wed".
+func FormatWeekdays(weekdays map[string]struct{}) string {
+	var ordered []string
+	for _, abbreviation := range WeekdayAbbreviations {
+		if _, present := weekdays[abbreviation]; present {
+			ordered = append(ordered, abbreviation)
+		}
+	}
+	return strings.Join(ordered, WeekdayListSeparator)
+}
+
+func weekdayIndexOf(abbreviation string) int {
+	for index, candidate := range WeekdayAbbreviations {
+		if strings.EqualFold(candidate, abbreviation) {
+			return index
+		}
+	}
+	return -1
+}
+
+// WeekdayAbbreviation returns "Mon".."Sun" for a date.
+func WeekdayAbbreviation(date time.Time) string {
+	return date.Weekday().String()[:3]
+}
+
+// CalendarDate strips the clock from a timestamp, keeping the UTC calendar day the scraper stored.
+func CalendarDate(timestamp time.Time) time.Time {
+	utc := timestamp.UTC()
+	return time.Date(utc.Year(), utc.Month(), utc.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// WeekStartOf returns the Monday of the week containing date.
+func WeekStartOf(date time.Time) time.Time {
+	return date.AddDate(0, 0, -(int(date.Weekday())+6)%7)
+}
+
+// WindowsOn returns the [start, end) clock spans of the windows in effect on a date.
+func WindowsOn(date time.Time, windows []WeeklyWindow) [][2]int {
+	var spans [][2]int
+	for _, window := range windows {
+		if window.AppliesOn(date) {
+			spans = append(spans, [2]int{window.StartClockMinutes, window.EndClockMinutes})
+		}
+	}
+	return spans
+}
+
+// CoveredMinutes counts the session minutes falling inside the required coverage windows.
+func CoveredMinutes(session Session, windows []WeeklyWindow) int {
+	total := 0
+	for _, date := range session.MeetingDates() {
+		for _, window := range windows {
+			if window.AppliesOn(date) {
+				total += ClockOverlapMinutes(session.StartClockMinutes(), session.EndClockMinutes(), window.StartClockMinutes, window.EndClockMinutes)
+			}
+		}
+	}
+	return total
+}
+
+// RequiredMinutes totals the coverage demanded by the windows over their whole date span.
+func RequiredMinutes(windows []WeeklyWindow) int {
+	if len(windows) == 0 {
+		return 0
+	}
+	firstDate, lastDate := windows[0].FromDate, windows[0].ToDate
+	for _, window := range windows[1:] {
+		if window.FromDate.Before(firstDate) {
+			firstDate = window.FromDate
+		}
+		if window.ToDate.After(lastDate) {
+			lastDate = window.ToDate
+		}
+	}
+	total := 0
+	for date := firstDate; !date.After(lastDate); date = date.AddDate(0, 0, 1) {
+		for _, window := range windows {
+			if window.AppliesOn(date) {
+				total += window.EndClockMinutes - window.StartClockMinutes
+			}
+		}
+	}
+	return total
+}
+
+// ClockOverlapMinutes is the length of the overlap of two [start, end) clock spans.
+func ClockOverlapMinutes(startA, endA, startB, endB int) int {
+	overlapStart := max(startA, startB)
+	overlapEnd := min(endA, endB)
+	if overlapEnd <= overlapStart {
+		return 0
+	}
+	return overlapEnd - overlapStart
+}
+
+// BlackedOutMeetingDates returns the session meeting dates that fall inside any blackout.
+func BlackedOutMeetingDates(session Session, blackouts []Blackout) []time.Time {
+	if len(blackouts) == 0 {
+		return nil
+	}
+	var missedDates []time.Time
+	for _, date := range session.MeetingDates() {
+		if DateIsBlackedOut(date, blackouts) {
+			missedDates = append(missedDates, date)
+		}
+	}
+	return missedDates
+}
+
+// DateIsBlackedOut reports whether date falls inside any of the blackouts.
+func DateIsBlackedOut(date time.Time, blackouts []Blackout) bool {
+	for _, blackout := range blackouts {
+		if !date.Before(blackout.FromDate) && !date.After(blackout.ToDate) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+// planner/calendar_test.go
+package planner
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCoveredMinutes(t *testing.T) {
+	const week = "2025-06-16"
+	windows := []WeeklyWindow{testWindow(week, "09:00", "15:00")}
+	if got := RequiredMinutes(windows); got != 5*360 {
+		t.Errorf("RequiredMinutes = %d, want %d", got, 5*360)
+	}
+	if got := CoveredMinutes(testSession("Art", week, "08:00", "12:00"), windows); got != 5*180 {
+		t.Errorf("CoveredMinutes = %d, want %d", got, 5*180)
+	}
+	if got := CoveredMinutes(testSession("Art", "2025-06-23", "09:00", "12:00"), windows); got != 0 {
+		t.Errorf("CoveredMinutes the week after = %d, want 0", got)
+	}
+}
+
+func TestExpandWeekdays(t *testing.T) {
+	tests := []struct {
+		days string
+		want string
+	}{
+		{days: "Mon-Fri", want: "Mon,Tue,Wed,Thu,Fri"},
+		{days: "Fri-Mon", want: "Mon,Fri,Sat,Sun"},
+		{days: "Mon, Wed,Fri", want: "Mon,Wed,Fri"},
+	}
+	for _, test := range tests {
+		weekdays, expandError := ExpandWeekdays(test.days)
+		if expandError != nil {
+			t.Fatal(expandError)
+		}
+		if got := FormatWeekdays(weekdays); got != test.want {
+			t.Errorf("ExpandWeekdays(%q) = %s, want %s", test.days, got, test.want)
+		}
+	}
+	if _, expandError := ExpandWeekdays("Mon-Funday"); expandError == nil {
+		t.Error("an unknown weekday expanded")
+	}
+}
+
+func TestWeekStartOf(t *testing.T) {
+	for _, day := range []int{16, 18, 22} {
+		date := time.Date(2025, time.June, day, 0, 0, 0, 0, time.UTC)
+		if got := WeekStartOf(date).Format(dateLayoutISOLiteral); got != "2025-06-16" {
+			t.Errorf("WeekStartOf(%s) = %s, want 2025-06-16", date.Format(dateLayoutISOLiteral), got)
+		}
+	}
+}
+
+func TestBlackedOutMeetingDates(t *testing.T) {
+	session := testSession("Art", "2025-06-16", "09:00", "12:00")
+	var missed []string
+	for _, date := range BlackedOutMeetingDates(session, []Blackout{testBlackout("2025-06-19", "2025-06-22")}) {
+		missed = append(missed, date.Format(dateLayoutISOLiteral))
+	}
+	if len(missed) != 2 || missed[0] != "2025-06-19" || missed[1] != "2025-06-20" {
+		t.Errorf("missed %q, want the Thursday and Friday", missed)
+	}
+}
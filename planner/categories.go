@@ -0,0 +1,143 @@
+// planner/categories.go
+package planner
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// CategoryRule tags every session whose title matches Pattern with Category.
+type CategoryRule struct {
+	Pattern  *regexp.Regexp
+	Category string
+}
+
+// CategoryTable is an ordered list of title patterns; a session takes the
+// category of every rule it matches.
+type CategoryTable []CategoryRule
+
+// CategoryLimit bounds one child's sessions of a category. Zero fields are not
+// checked; an unmet MinSessions is reported like a Must activity.
+type CategoryLimit struct {
+	MinSessions int
+	MaxSessions int
+	MaxWeeks    int
+}
+
+// CategoriesOf lists the distinct categories whose patterns match the title, sorted.
+func (table CategoryTable) CategoriesOf(title string) []string {
+	seen := map[string]struct{}{}
+	var categories []string
+	for _, rule := range table {
+		if _, duplicate := seen[rule.Category]; duplicate || !rule.Pattern.MatchString(title) {
+			continue
+		}
+		seen[rule.Category] = struct{}{}
+		categories = append(categories, rule.Category)
+	}
+	sort.Strings(categories)
+	return categories
+}
+
+// Tag sets the Categories of every session from its title.
+func (table CategoryTable) Tag(sessions []Session) {
+	for index := range sessions {
+		sessions[index].Categories = table.CategoriesOf(sessions[index].ActivityName)
+	}
+}
+
+// HasCategory reports whether the session is tagged with the category.
+func (session Session) HasCategory(category string) bool {
+	for _, tagged := range session.Categories {
+		if tagged == category {
+			return true
+		}
+	}
+	return false
+}
+
+// CategorySessions counts the plan's sessions in the category.
+func (plan *Plan) CategorySessions(category string) int {
+	count := 0
+	for _, session := range plan.Sessions {
+		if session.HasCategory(category) {
+			count++
+		}
+	}
+	return count
+}
+
+// withinCategoryLimits checks the child's category maximums with the candidate added.
+func (plan *Plan) withinCategoryLimits(candidate Session) bool {
+	for _, category := range candidate.Categories {
+		limit := plan.Child.CategoryLimits[category]
+		if limit.MaxSessions > 0 && plan.CategorySessions(category)+1 > limit.MaxSessions {
+			return false
+		}
+		if limit.MaxWeeks > 0 {
+			weeks := candidate.weeks()
+			for _, session := range plan.Sessions {
+				if !session.HasCategory(category) {
+					continue
+				}
+				for weekStart := range session.weeks() {
+					weeks[weekStart] = struct{}{}
+				}
+			}
+			if len(weeks) > limit.MaxWeeks {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// categoryGain is what the candidate adds for one child beyond its priority:
+// a Must-sized score for each category still short of its minimum and the
+// diversity bonus for each category the child has no session of yet.
+func (plan *Plan) categoryGain(candidate Session, diversityBonus int) int {
+	gain := 0
+	for _, category := range candidate.Categories {
+		held := plan.CategorySessions(category)
+		if held == 0 {
+			gain += diversityBonus
+		}
+		if held < plan.Child.CategoryLimits[category].MinSessions {
+			gain += MustPriorityScore
+		}
+	}
+	return gain
+}
+
+// diversityScore is the diversity bonus for each distinct category in the
+// plan, as is and weighted by the best success probability among its sessions.
+func (plan *Plan) diversityScore(options Options) (int, float64) {
+	if options.DiversityBonus == 0 {
+		return 0, 0
+	}
+	bestProbability := map[string]float64{}
+	for _, session := range plan.Sessions {
+		for _, category := range session.Categories {
+			bestProbability[category] = max(bestProbability[category], options.SuccessProbability(session))
+		}
+	}
+	expected := 0.0
+	for _, probability := range bestProbability {
+		expected += float64(options.DiversityBonus) * probability
+	}
+	return options.DiversityBonus * len(bestProbability), expected
+}
+
+// unmetCategoryMinimums lists "child: at least N category sessions" for every category minimum a plan misses.
+func unmetCategoryMinimums(plans map[string]*Plan) []string {
+	var unmet []string
+	for childName, plan := range plans {
+		for category, limit := range plan.Child.CategoryLimits {
+			if plan.CategorySessions(category) < limit.MinSessions {
+				unmet = append(unmet, fmt.Sprintf("%s: at least %d %s sessions", childName, limit.MinSessions, category))
+			}
+		}
+	}
+	return unmet
+}
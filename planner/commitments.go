@@ -0,0 +1,56 @@
+// planner/commitments.go
+package planner
+
+import "time"
+
+// Commitment is a recurring non-camp block, such as swim team or tutoring,
+// that sessions must keep the buffer from like any other session.
+type Commitment struct {
+	WeeklyWindow
+	Note string
+}
+
+// CommitmentsOn returns the child's commitments in effect on a date.
+func (child Child) CommitmentsOn(date time.Time) []Commitment {
+	var commitments []Commitment
+	for _, commitment := range child.Commitments {
+		if commitment.AppliesOn(date) {
+			commitments = append(commitments, commitment)
+		}
+	}
+	return commitments
+}
+
+// clashesWithCommitment reports whether the candidate meets within the buffer of one of the child's commitments.
+func (plan *Plan) clashesWithCommitment(candidate Session) bool {
+	if len(plan.Child.Commitments) == 0 {
+		return false
+	}
+	for _, date := range candidate.MeetingDates() {
+		for _, commitment := range plan.Child.CommitmentsOn(date) {
+			if candidate.EndClockMinutes()+plan.bufferMinutes > commitment.StartClockMinutes &&
+				commitment.EndClockMinutes+plan.bufferMinutes > candidate.StartClockMinutes() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CommittedMinutes counts the commitment minutes falling inside the required coverage windows.
+func CommittedMinutes(commitments []Commitment, windows []WeeklyWindow) int {
+	total := 0
+	for _, commitment := range commitments {
+		for date := commitment.FromDate; !date.After(commitment.ToDate); date = date.AddDate(0, 0, 1) {
+			if !commitment.AppliesOn(date) {
+				continue
+			}
+			for _, window := range windows {
+				if window.AppliesOn(date) {
+					total += ClockOverlapMinutes(commitment.StartClockMinutes, commitment.EndClockMinutes, window.StartClockMinutes, window.EndClockMinutes)
+				}
+			}
+		}
+	}
+	return total
+}
@@ -0,0 +1,209 @@
+// planner/drivers.go
+package planner
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTravelMinutes is the one-way time from home to a facility missing from the travel table.
+	DefaultTravelMinutes = 15
+	dropOffLiteral       = "drop-off"
+	pickUpLiteral        = "pick-up"
+)
+
+// driverStop is one moment a driver must be at a facility.
+type driverStop struct {
+	clockMinutes int
+	location     string
+	kind         string
+	childNames   []string
+	activityName string
+}
+
+// driverState tracks where a driver is, when they are next free, and the
+// clock windows their own calendar blocks on the day being checked.
+type driverState struct {
+	location    string
+	freeAtClock int
+	hasDriven   bool
+	busyWindows [][2]int
+}
+
+// travelMinutesBetween approximates a trip between two stops by routing through home;
+// the empty location is home itself.
+func travelMinutesBetween(fromLocation, toLocation string, minutesByLocation map[string]int) int {
+	if fromLocation == toLocation {
+		return 0
+	}
+	return homeTravelMinutes(fromLocation, minutesByLocation) + homeTravelMinutes(toLocation, minutesByLocation)
+}
+
+func homeTravelMinutes(location string, minutesByLocation map[string]int) int {
+	if location == emptyLiteral {
+		return 0
+	}
+	if minutes, known := minutesByLocation[location]; known {
+		return minutes
+	}
+	return DefaultTravelMinutes
+}
+
+// driverRunIsFeasible checks whether the family's drivers can cover the new session's
+// drop-offs and pick-ups on every meeting date, given everything already planned.
+// It returns an explanation for the first date that fails.
+func driverRunIsFeasible(plans map[string]*Plan, candidate Session, joiningChildren []string, options Options) (bool, string) {
+	if options.DriversByWeekday == nil && options.DriverCalendars == nil {
+		return true, emptyLiteral
+	}
+	for _, date := range candidate.MeetingDates() {
+		driverCount, constrained := options.DriversByWeekday[WeekdayAbbreviation(date)]
+		if !constrained {
+			if options.DriverCalendars == nil {
+				continue
+			}
+			driverCount = len(options.DriverCalendars)
+		}
+
+		var ridingChildren []string
+		for _, childName := range joiningChildren {
+			if !DateIsBlackedOut(date, plans[childName].Child.Blackouts) {
+				ridingChildren = append(ridingChildren, childName)
+			}
+		}
+		if len(ridingChildren) == 0 {
+			continue
+		}
+
+		stops := driverStopsOn(plans, date)
+		stops = appendSessionStops(stops, candidate, ridingChildren)
+		drivers := make([]driverState, driverCount)
+		for driverIndex := range drivers {
+			if driverIndex < len(options.DriverCalendars) {
+				drivers[driverIndex].busyWindows = WindowsOn(date, options.DriverCalendars[driverIndex])
+			}
+		}
+		if failedStop, feasible := scheduleDriverStops(stops, drivers, options.TravelMinutesByLocation); !feasible {
+			return false, fmt.Sprintf("%s: %s %s at %s, %s on %s %s has no free driver (%d available)",
+				strings.Join(ridingChildren, siblingSeparatorLiteral), candidate.ActivityName, failedStop.kind,
+				MinutesToMilitaryTime(failedStop.clockMinutes), failedStop.location,
+				WeekdayAbbreviation(date), date.Format(dateLayoutISOLiteral), driverCount)
+		}
+	}
+	return true, emptyLiteral
+}
+
+// driverStopsOn collects the drop-offs and pick-ups already planned for a date,
+// child by child in name order so merged stops list siblings the same way every run.
+func driverStopsOn(plans map[string]*Plan, date time.Time) []driverStop {
+	var stops []driverStop
+	for _, childName := range slices.Sorted(maps.Keys(plans)) {
+		plan := plans[childName]
+		if DateIsBlackedOut(date, plan.Child.Blackouts) {
+			continue
+		}
+		for _, session := range plan.Sessions {
+			for _, meetingDate := range session.MeetingDates() {
+				if meetingDate.Equal(date) {
+					stops = appendSessionStops(stops, session, []string{childName})
+					break
+				}
+			}
+		}
+	}
+	return stops
+}
+
+// appendSessionStops adds a session's drop-off and pick-up, merging with an existing
+// stop at the same place and minute so siblings ride together. Sessions without a
+// scraped location are treated as their own facility.
+func appendSessionStops(stops []driverStop, session Session, childNames []string) []driverStop {
+	location := session.Location
+	if location == emptyLiteral {
+		location = session.ActivityName
+	}
+	for _, stop := range []driverStop{
+		{clockMinutes: session.StartClockMinutes(), location: location, kind: dropOffLiteral, activityName: session.ActivityName},
+		{clockMinutes: session.EndClockMinutes(), location: location, kind: pickUpLiteral, activityName: session.ActivityName},
+	} {
+		merged := false
+		for index := range stops {
+			if stops[index].clockMinutes == stop.clockMinutes && stops[index].location == stop.location && stops[index].kind == stop.kind {
+				stops[index].childNames = append(stops[index].childNames, childNames...)
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			stop.childNames = append([]string(nil), childNames...)
+			stops = append(stops, stop)
+		}
+	}
+	return stops
+}
+
+// scheduleDriverStops assigns stops in time order to the driver who can reach each one
+// with the least slack. Stops at the same minute go by child name, drop-offs before
+// pick-ups, then facility, so the same plan always fails at the same stop.
+// A driver is unusable while their calendar is busy between leaving for a stop
+// and, after a pick-up, getting the child home; a driver with a commitment since
+// their last stop sets out from home. It returns the first unreachable stop.
+func scheduleDriverStops(stops []driverStop, drivers []driverState, minutesByLocation map[string]int) (driverStop, bool) {
+	sort.SliceStable(stops, func(i, j int) bool {
+		if stops[i].clockMinutes != stops[j].clockMinutes {
+			return stops[i].clockMinutes < stops[j].clockMinutes
+		}
+		if stops[i].childNames[0] != stops[j].childNames[0] {
+			return stops[i].childNames[0] < stops[j].childNames[0]
+		}
+		if stops[i].kind != stops[j].kind {
+			return stops[i].kind == dropOffLiteral
+		}
+		return stops[i].location < stops[j].location
+	})
+	for _, stop := range stops {
+		bestDriver, bestSlack := -1, 0
+		for driverIndex, driver := range drivers {
+			origin := driver.location
+			if driver.isBusyBetween(driver.freeAtClock, stop.clockMinutes) {
+				origin = emptyLiteral
+			}
+			departure := stop.clockMinutes - travelMinutesBetween(origin, stop.location, minutesByLocation)
+			if driver.hasDriven && departure < driver.freeAtClock {
+				continue
+			}
+			tripEnd := stop.clockMinutes
+			if stop.kind == pickUpLiteral {
+				tripEnd += homeTravelMinutes(stop.location, minutesByLocation)
+			}
+			if driver.isBusyBetween(departure, tripEnd) {
+				continue
+			}
+			slack := departure - driver.freeAtClock
+			if bestDriver < 0 || slack < bestSlack {
+				bestDriver, bestSlack = driverIndex, slack
+			}
+		}
+		if bestDriver < 0 {
+			return stop, false
+		}
+		drivers[bestDriver].location = stop.location
+		drivers[bestDriver].freeAtClock = stop.clockMinutes
+		drivers[bestDriver].hasDriven = true
+	}
+	return driverStop{}, true
+}
+
+func (driver driverState) isBusyBetween(startClock, endClock int) bool {
+	for _, busy := range driver.busyWindows {
+		if ClockOverlapMinutes(startClock, endClock, busy[0], busy[1]) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+// planner/drivers_test.go
+package planner
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestScheduleDriverStopsIsOrderIndependent(t *testing.T) {
+	stops := []driverStop{
+		{clockMinutes: 720, location: "Pool", kind: pickUpLiteral, childNames: []string{"Bob"}},
+		{clockMinutes: 720, location: "Studio", kind: dropOffLiteral, childNames: []string{"Alice"}},
+		{clockMinutes: 720, location: "Rink", kind: pickUpLiteral, childNames: []string{"Alice"}},
+	}
+	want, feasible := scheduleDriverStops(slices.Clone(stops), make([]driverState, 1), nil)
+	if feasible {
+		t.Fatal("one driver reached three places at once")
+	}
+	if want.location != "Rink" {
+		t.Errorf("first unreachable stop is %s, want Alice's Rink pick-up after her Studio drop-off", want.location)
+	}
+	slices.Reverse(stops)
+	if got, _ := scheduleDriverStops(stops, make([]driverState, 1), nil); got.location != want.location || got.kind != want.kind {
+		t.Errorf("reordered stops fail at %s %s, want %s %s", got.kind, got.location, want.kind, want.location)
+	}
+}
+
+func TestTravelMinutesBetween(t *testing.T) {
+	minutesByLocation := map[string]int{"Pool": 10, "Studio": 20}
+	tests := []struct {
+		from, to string
+		want     int
+	}{
+		{from: "Pool", to: "Pool", want: 0},
+		{from: "Pool", to: "Studio", want: 30},
+		{from: emptyLiteral, to: "Studio", want: 20},
+		{from: "Pool", to: "Rink", want: 10 + DefaultTravelMinutes},
+	}
+	for _, test := range tests {
+		if got := travelMinutesBetween(test.from, test.to, minutesByLocation); got != test.want {
+			t.Errorf("travelMinutesBetween(%q, %q) = %d, want %d", test.from, test.to, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// planner/fairness.go
+package planner
+
+import (
+	"sort"
+	"strings"
+)
+
+// Fairness names how the planner balances siblings against the total score.
+type Fairness string
+
+const (
+	// FairnessNone maximises the total score only.
+	FairnessNone Fairness = emptyLiteral
+	// FairnessMaxMin serves the least satisfied sibling first.
+	FairnessMaxMin Fairness = "max-min"
+)
+
+// PrioritySatisfaction counts one priority's wishes and how many were planned.
+type PrioritySatisfaction struct {
+	Priority  string
+	Wished    int
+	Fulfilled int
+}
+
+// ChildSatisfaction sums up how many of a child's wishes the plan fulfils.
+// A wish is an activity the child gave a non-zero priority and has at least
+// one eligible session of; ByPriority runs from the highest score down.
+// WishedScore and FulfilledScore add up the wishes' priority scores, leaving
+// out Must activities, which are requirements rather than wishes.
+type ChildSatisfaction struct {
+	Wished         int
+	Fulfilled      int
+	WishedScore    int
+	FulfilledScore int
+	ByPriority     []PrioritySatisfaction
+}
+
+// Percent is the share of wished priority score fulfilled, so a High wish
+// weighs more than a Low one; 100 for a child without scored wishes.
+func (satisfaction ChildSatisfaction) Percent() int {
+	if satisfaction.WishedScore == 0 {
+		return 100
+	}
+	return satisfaction.FulfilledScore * 100 / satisfaction.WishedScore
+}
+
+// wishes maps each child to the priority of every activity they wish for.
+func wishes(sessions []Session, preferences Preferences, options Options) map[string]map[string]string {
+	wishesByChild := map[string]map[string]string{}
+	for _, child := range preferences.Children {
+		wishesByChild[child.Name] = map[string]string{}
+	}
+	for _, session := range sessions {
+		if !options.eligible(session) {
+			continue
+		}
+		for _, child := range preferences.Children {
+			if preferences.Wants(child.Name, session) {
+				wishesByChild[child.Name][session.ActivityKey()] = preferences.PriorityOf(child.Name, session)
+			}
+		}
+	}
+	return wishesByChild
+}
+
+// satisfactions measures every child's plan against their wishes.
+func satisfactions(plans map[string]*Plan, sessions []Session, preferences Preferences, options Options) map[string]ChildSatisfaction {
+	satisfactionByChild := map[string]ChildSatisfaction{}
+	for childName, wished := range wishes(sessions, preferences, options) {
+		byPriority := map[string]*PrioritySatisfaction{}
+		var satisfaction ChildSatisfaction
+		for activityKey, priorityValue := range wished {
+			label := priorityLabel(priorityValue)
+			if byPriority[label] == nil {
+				byPriority[label] = &PrioritySatisfaction{Priority: label}
+			}
+			byPriority[label].Wished++
+			satisfaction.Wished++
+			satisfaction.WishedScore += wishScore(preferences.Scale, priorityValue)
+			if plans[childName] != nil && plans[childName].Enrolled(activityKey) {
+				byPriority[label].Fulfilled++
+				satisfaction.Fulfilled++
+				satisfaction.FulfilledScore += wishScore(preferences.Scale, priorityValue)
+			}
+		}
+		for _, counts := range byPriority {
+			satisfaction.ByPriority = append(satisfaction.ByPriority, *counts)
+		}
+		sort.Slice(satisfaction.ByPriority, func(i, j int) bool {
+			left, right := satisfaction.ByPriority[i], satisfaction.ByPriority[j]
+			if leftScore, rightScore := preferences.Scale.Score(left.Priority), preferences.Scale.Score(right.Priority); leftScore != rightScore {
+				return leftScore > rightScore
+			}
+			return left.Priority < right.Priority
+		})
+		satisfactionByChild[childName] = satisfaction
+	}
+	return satisfactionByChild
+}
+
+// wishScore is the priority's score, zero for a Must.
+func wishScore(scale PriorityScale, priorityValue string) int {
+	score, must, _ := scale.Lookup(priorityValue)
+	if must {
+		return 0
+	}
+	return score
+}
+
+// priorityLabel capitalises a priority value the way PriorityScale.Words does.
+func priorityLabel(value string) string {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	if normalized == emptyLiteral {
+		return normalized
+	}
+	return strings.ToUpper(normalized[:1]) + normalized[1:]
+}
+
+// lessSatisfied reports whether fulfilled/wished is below otherFulfilled/otherWished;
+// nothing wished counts as fully satisfied.
+func lessSatisfied(fulfilled, wished, otherFulfilled, otherWished int) bool {
+	if wished == 0 {
+		fulfilled, wished = 1, 1
+	}
+	if otherWished == 0 {
+		otherFulfilled, otherWished = 1, 1
+	}
+	return fulfilled*otherWished < otherFulfilled*wished
+}
@@ -0,0 +1,110 @@
+// planner/gaps.go
+package planner
+
+import "time"
+
+const (
+	// DefaultDayStartClockMinutes and DefaultDayEndClockMinutes bound the working
+	// day gaps are measured against when a child has no coverage windows.
+	DefaultDayStartClockMinutes = 8*60 + 30
+	DefaultDayEndClockMinutes   = 17*60 + 30
+	noonClockMinutes            = 12 * 60
+	// GapNoSession, GapMorningOnly and GapAfternoonOnly describe a DayGap.
+	GapNoSession     = "no session"
+	GapMorningOnly   = "morning only"
+	GapAfternoonOnly = "afternoon only"
+)
+
+// DayGap describes how much of one weekday a child's plan leaves open.
+// Description is empty when both halves of the day have a session.
+// Commitments on the day count as covered but not as camp.
+type DayGap struct {
+	Date             time.Time
+	Description      string
+	UncoveredMinutes int
+	Commitments      []Commitment
+}
+
+// SummerDateRange returns the first and last calendar date across all sessions.
+func SummerDateRange(sessions []Session) (time.Time, time.Time, bool) {
+	if len(sessions) == 0 {
+		return time.Time{}, time.Time{}, false
+	}
+	firstDate, lastDate := CalendarDate(sessions[0].StartDate()), CalendarDate(sessions[0].EndDate())
+	for _, session := range sessions[1:] {
+		if date := CalendarDate(session.StartDate()); date.Before(firstDate) {
+			firstDate = date
+		}
+		if date := CalendarDate(session.EndDate()); date.After(lastDate) {
+			lastDate = date
+		}
+	}
+	return firstDate, lastDate, true
+}
+
+// Gaps walks every weekday from firstDate to lastDate and reports the days the plan leaves open.
+// Required hours come from the child's coverage windows, or the default working day without them;
+// blacked-out days are skipped since nobody needs care while away.
+func (plan *Plan) Gaps(firstDate, lastDate time.Time) []DayGap {
+	sessionsByDate := map[time.Time][]Session{}
+	for _, session := range plan.Sessions {
+		for _, date := range session.MeetingDates() {
+			sessionsByDate[date] = append(sessionsByDate[date], session)
+		}
+	}
+
+	var gaps []DayGap
+	for date := firstDate; !date.After(lastDate); date = date.AddDate(0, 0, 1) {
+		if weekday := date.Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+			continue
+		}
+		if DateIsBlackedOut(date, plan.Child.Blackouts) {
+			continue
+		}
+
+		morningCovered, afternoonCovered := false, false
+		for _, session := range sessionsByDate[date] {
+			if session.StartClockMinutes() < noonClockMinutes {
+				morningCovered = true
+			}
+			if session.EndClockMinutes() > noonClockMinutes {
+				afternoonCovered = true
+			}
+		}
+
+		commitments := plan.Child.CommitmentsOn(date)
+		uncovered := 0
+		for _, required := range requiredWindowsOn(date, plan.Child.Coverage) {
+			requiredMinutes := required[1] - required[0]
+			for _, session := range sessionsByDate[date] {
+				requiredMinutes -= ClockOverlapMinutes(session.StartClockMinutes(), session.EndClockMinutes(), required[0], required[1])
+			}
+			for _, commitment := range commitments {
+				requiredMinutes -= ClockOverlapMinutes(commitment.StartClockMinutes, commitment.EndClockMinutes, required[0], required[1])
+			}
+			uncovered += max(requiredMinutes, 0)
+		}
+
+		description := emptyLiteral
+		switch {
+		case !morningCovered && !afternoonCovered:
+			description = GapNoSession
+		case !afternoonCovered:
+			description = GapMorningOnly
+		case !morningCovered:
+			description = GapAfternoonOnly
+		}
+		if description != emptyLiteral || uncovered > 0 {
+			gaps = append(gaps, DayGap{Date: date, Description: description, UncoveredMinutes: uncovered, Commitments: commitments})
+		}
+	}
+	return gaps
+}
+
+// requiredWindowsOn returns the [start, end) clock windows needing coverage on a date.
+func requiredWindowsOn(date time.Time, windows []WeeklyWindow) [][2]int {
+	if len(windows) == 0 {
+		return [][2]int{{DefaultDayStartClockMinutes, DefaultDayEndClockMinutes}}
+	}
+	return WindowsOn(date, windows)
+}
@@ -0,0 +1,62 @@
+// planner/gaps_test.go
+package planner
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPlanGaps(t *testing.T) {
+	art := testSession("Art", "2025-06-16", "09:00", "12:00")
+	firstDate := time.Date(2025, time.June, 16, 0, 0, 0, 0, time.UTC)
+	lastDate := firstDate.AddDate(0, 0, 13)
+
+	tests := []struct {
+		name  string
+		child Child
+		want  map[string]DayGap
+		count int
+	}{
+		{
+			name:  "the default working day needs covering on every weekday",
+			child: Child{Name: "Alice"},
+			want: map[string]DayGap{
+				"2025-06-16": {Description: GapMorningOnly, UncoveredMinutes: 540 - 180},
+				"2025-06-23": {Description: GapNoSession, UncoveredMinutes: 540},
+			},
+			count: 10,
+		},
+		{
+			name:  "coverage windows set the hours needing cover",
+			child: Child{Name: "Alice", Coverage: []WeeklyWindow{testWindow("2025-06-16", "09:00", "12:00")}},
+			want: map[string]DayGap{
+				"2025-06-16": {Description: GapMorningOnly},
+				"2025-06-23": {Description: GapNoSession},
+			},
+			count: 10,
+		},
+		{
+			name:  "blacked-out days need no cover",
+			child: Child{Name: "Alice", Blackouts: []Blackout{testBlackout("2025-06-23", "2025-06-29")}},
+			want: map[string]DayGap{
+				"2025-06-16": {Description: GapMorningOnly, UncoveredMinutes: 540 - 180},
+			},
+			count: 5,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			plan := &Plan{Child: test.child, Sessions: []Session{art}}
+			gaps := plan.Gaps(firstDate, lastDate)
+			if len(gaps) != test.count {
+				t.Fatalf("got %d gaps, want %d", len(gaps), test.count)
+			}
+			for _, gap := range gaps {
+				want, checked := test.want[gap.Date.Format(dateLayoutISOLiteral)]
+				if checked && (gap.Description != want.Description || gap.UncoveredMinutes != want.UncoveredMinutes) {
+					t.Errorf("%s = %q %d minutes, want %q %d minutes", gap.Date.Format(dateLayoutISOLiteral), gap.Description, gap.UncoveredMinutes, want.Description, want.UncoveredMinutes)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,279 @@
+// planner/households.go
+package planner
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultFriendBonus is added for every pair of friends sharing a session.
+const DefaultFriendBonus = 1
+
+var pricePattern = regexp.MustCompile(`\d[\d,]*(?:\.\d+)?`)
+
+// Household is one family of a plan made for several: its children share the
+// togetherness bonus, the drivers and the budget, while children of other
+// households only count as friends.
+type Household struct {
+	Name       string
+	ChildNames []string
+	// Budget caps the summed prices of the household's sessions; 0 is unlimited.
+	Budget float64
+}
+
+// Cost parses the session's price text, e.g. "$1,245.00"; known is false
+// when the text holds no amount.
+func (session Session) Cost() (float64, bool) {
+	amountText := pricePattern.FindString(session.Price)
+	if amountText == emptyLiteral {
+		return 0, false
+	}
+	amount, parseError := strconv.ParseFloat(strings.ReplaceAll(amountText, ",", emptyLiteral), 64)
+	return amount, parseError == nil
+}
+
+// households returns the preferences' households, or a single one of every
+// child with the family budget when none are given.
+func (preferences Preferences) households() []Household {
+	if len(preferences.Households) > 0 {
+		return preferences.Households
+	}
+	return []Household{{ChildNames: preferences.ChildNames(), Budget: preferences.Budget}}
+}
+
+// validateHouseholds checks that every child belongs to exactly one household.
+func validateHouseholds(preferences Preferences, childNames []string) error {
+	householdByChild := map[string]string{}
+	householdNames := map[string]struct{}{}
+	for _, household := range preferences.Households {
+		if _, duplicate := householdNames[household.Name]; duplicate {
+			return fmt.Errorf("duplicate household %q", household.Name)
+		}
+		householdNames[household.Name] = struct{}{}
+		if household.Budget < 0 {
+			return fmt.Errorf("household %q has a negative budget", household.Name)
+		}
+		for _, childName := range household.ChildNames {
+			if _, known := preferences.ChildNamed(childName); !known {
+				return fmt.Errorf("household %q: unknown child %q", household.Name, childName)
+			}
+			if other, taken := householdByChild[childName]; taken {
+				return fmt.Errorf("child %q is in households %q and %q", childName, other, household.Name)
+			}
+			householdByChild[childName] = household.Name
+		}
+	}
+	if len(preferences.Households) == 0 {
+		return nil
+	}
+	for _, childName := range childNames {
+		if _, placed := householdByChild[childName]; !placed {
+			return fmt.Errorf("child %q is in no household", childName)
+		}
+	}
+	return nil
+}
+
+// householdIndex maps each child to the index of their household.
+func householdIndex(households []Household) map[string]int {
+	indexByChild := map[string]int{}
+	for householdIndex, household := range households {
+		for _, childName := range household.ChildNames {
+			indexByChild[childName] = householdIndex
+		}
+	}
+	return indexByChild
+}
+
+// friendsOf makes every friendship between planned children mutual.
+func friendsOf(preferences Preferences) map[string]map[string]struct{} {
+	friendsByChild := map[string]map[string]struct{}{}
+	befriend := func(childName, friendName string) {
+		if friendsByChild[childName] == nil {
+			friendsByChild[childName] = map[string]struct{}{}
+		}
+		friendsByChild[childName][friendName] = struct{}{}
+	}
+	for _, child := range preferences.Children {
+		for _, friendName := range child.Friends {
+			if _, known := preferences.ChildNamed(friendName); known && friendName != child.Name {
+				befriend(child.Name, friendName)
+				befriend(friendName, child.Name)
+			}
+		}
+	}
+	return friendsByChild
+}
+
+// friendPairs counts the friendships among the children.
+func friendPairs(friendsByChild map[string]map[string]struct{}, childNames []string) int {
+	pairs := 0
+	for firstIndex, childName := range childNames {
+		for _, otherName := range childNames[firstIndex+1:] {
+			if _, friends := friendsByChild[childName][otherName]; friends {
+				pairs++
+			}
+		}
+	}
+	return pairs
+}
+
+// householdPlans picks the household's plans out of all plans.
+func householdPlans(plans map[string]*Plan, household Household) map[string]*Plan {
+	selected := map[string]*Plan{}
+	for _, childName := range household.ChildNames {
+		if plan, planned := plans[childName]; planned {
+			selected[childName] = plan
+		}
+	}
+	return selected
+}
+
+// spent sums the known prices of every session planned for the household's children.
+func spent(plans map[string]*Plan, household Household) float64 {
+	total := 0.0
+	for _, plan := range householdPlans(plans, household) {
+		for _, session := range plan.Sessions {
+			cost, _ := session.Cost()
+			total += cost
+		}
+	}
+	return total
+}
+
+// withinBudget reports whether joining children can take the candidate on
+// top of spentSoFar; sessions without a price always fit.
+func (household Household) withinBudget(spentSoFar float64, candidate Session, joining int) bool {
+	cost, known := candidate.Cost()
+	return household.Budget <= 0 || !known || spentSoFar+cost*float64(joining) <= household.Budget
+}
+
+// takenBy names who holds a full session's spots as the child's family may
+// see it: siblings and friends by name, children of other families only as a count.
+func takenBy(attending []string, household Household, friends map[string]struct{}) string {
+	var named []string
+	others := 0
+	for _, attendee := range attending {
+		if _, friend := friends[attendee]; friend || containsString(household.ChildNames, attendee) {
+			named = append(named, attendee)
+		} else {
+			others++
+		}
+	}
+	switch others {
+	case 0:
+	case 1:
+		named = append(named, "1 child of another family")
+	default:
+		named = append(named, fmt.Sprintf("%d children of other families", others))
+	}
+	return strings.Join(named, ", ")
+}
+
+func budgetRejection(childName string, candidate Session, household Household, spentSoFar float64) string {
+	cost, _ := candidate.Cost()
+	return fmt.Sprintf("%s: %s %s costs $%.2f, $%.2f of the budget left",
+		childName, candidate.ActivityName, candidate.StartDate().Format(dateLayoutISOLiteral), cost, max(household.Budget-spentSoFar, 0))
+}
+
+// HouseholdOf returns the household the child belongs to.
+func (result *Result) HouseholdOf(childName string) Household {
+	for _, household := range result.Households {
+		if containsString(household.ChildNames, childName) {
+			return household
+		}
+	}
+	return Household{}
+}
+
+// Spent sums the known prices of the household's planned sessions.
+func (result *Result) Spent(household Household) float64 {
+	return spent(result.Plans, household)
+}
+
+// FriendsSharing lists the child's friends, from any household, whose plan contains the same session.
+func (result *Result) FriendsSharing(childName string, session Session) []string {
+	var friends []string
+	for _, attendee := range result.attendees[session.Key()] {
+		if _, friend := result.friends[childName][attendee]; friend {
+			friends = append(friends, attendee)
+		}
+	}
+	sort.Strings(friends)
+	return friends
+}
+
+// ForHousehold narrows the result to one household, so it can be shared with
+// that family alone: their plans, the sessions all of their children attend,
+// and only the rejections, unmet requirements and satisfaction of their
+// children. Score is the household's own, friends left out. Spots taken,
+// backups and friends sharing a session still see every household.
+func (result *Result) ForHousehold(household Household) *Result {
+	childNames := append([]string(nil), household.ChildNames...)
+	sort.Strings(childNames)
+	view := &Result{
+		Plans:        householdPlans(result.Plans, household),
+		ChildNames:   childNames,
+		Households:   []Household{household},
+		Satisfaction: map[string]ChildSatisfaction{},
+		options:      result.options,
+		preferences:  result.preferences,
+		friends:      result.friends,
+		attendees:    result.attendees,
+	}
+	for childName, satisfaction := range result.Satisfaction {
+		if containsString(childNames, childName) {
+			view.Satisfaction[childName] = satisfaction
+		}
+	}
+	for _, rejection := range result.Rejections {
+		if concernsAny(rejection, childNames) {
+			view.Rejections = append(view.Rejections, rejection)
+		}
+	}
+	for _, unmet := range result.Unmet {
+		if concernsAny(unmet, childNames) {
+			view.Unmet = append(view.Unmet, unmet)
+		}
+	}
+	for _, session := range view.sessionsByStart() {
+		if len(view.Attendees(session)) == len(childNames) {
+			view.Joint = append(view.Joint, session)
+		}
+	}
+	view.Score, view.ExpectedScore = view.score(result.preferences, result.options)
+	return view
+}
+
+// sessionsByStart lists every planned session once, by start date.
+func (result *Result) sessionsByStart() []Session {
+	seen := map[string]struct{}{}
+	var sessions []Session
+	for _, childName := range result.ChildNames {
+		for _, session := range result.Plans[childName].Sessions {
+			if _, duplicate := seen[session.Key()]; !duplicate {
+				seen[session.Key()] = struct{}{}
+				sessions = append(sessions, session)
+			}
+		}
+	}
+	sort.SliceStable(sessions, func(i, j int) bool { return sessions[i].StartDate().Before(sessions[j].StartDate()) })
+	return sessions
+}
+
+// concernsAny reports whether a "child: ..." or "child, child: ..." line names one of the children.
+func concernsAny(line string, childNames []string) bool {
+	subject, _, found := strings.Cut(line, ": ")
+	if !found {
+		return false
+	}
+	for _, childName := range strings.Split(subject, siblingSeparatorLiteral) {
+		if containsString(childNames, childName) {
+			return true
+		}
+	}
+	return false
+}
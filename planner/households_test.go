@@ -0,0 +1,72 @@
+// planner/households_test.go
+package planner
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+// sessionKeys lists the sessions' keys.
+func sessionKeys(sessions []Session) []string {
+	var keys []string
+	for _, session := range sessions {
+		keys = append(keys, session.Key())
+	}
+	return keys
+}
+
+func TestForHouseholdSeesOtherHouseholds(t *testing.T) {
+	const week = "2025-06-16"
+	sessions := []Session{
+		testSession("Chess", week, "09:00", "12:00"),
+		testSession("Art", week, "09:00", "12:00"),
+		withAvailability(testSession("Art", "2025-06-23", "09:00", "12:00"), "2 spaces left"),
+		testSession("Swim", "2025-06-30", "09:00", "12:00"),
+	}
+	preferences := inHouseholds(withFriends(testPreferences(map[string]map[string]string{
+		"Chess": {"Zoe": PriorityMust},
+		"Art":   {"Alice": PriorityHigh, "Peter": PriorityHigh, "Zoe": PriorityHigh},
+		"Swim":  {"Alice": PriorityHigh, "Zoe": PriorityHigh},
+	}, "Alice", "Peter", "Zoe"), "Alice", "Zoe"),
+		Household{Name: "Smith", ChildNames: []string{"Alice", "Peter"}},
+		Household{Name: "Jones", ChildNames: []string{"Zoe"}})
+
+	result, planError := New(DefaultOptions()).Plan(context.Background(), sessions, preferences)
+	if planError != nil {
+		t.Fatalf("Plan: %v", planError)
+	}
+	view := result.ForHousehold(result.Households[0])
+
+	for _, childName := range view.ChildNames {
+		for _, session := range view.Plans[childName].Sessions {
+			if got, want := view.FriendsSharing(childName, session), result.FriendsSharing(childName, session); !slices.Equal(got, want) {
+				t.Errorf("view FriendsSharing(%s, %s) = %q, want %q", childName, session.Key(), got, want)
+			}
+			if got, want := sessionKeys(view.BackupsFor(session, []string{childName})), sessionKeys(result.BackupsFor(session, []string{childName})); !slices.Equal(got, want) {
+				t.Errorf("view BackupsFor(%s, %s) = %q, want %q", session.Key(), childName, got, want)
+			}
+			if got, want := view.SpotsTaken(session), result.SpotsTaken(session); got != want {
+				t.Errorf("view SpotsTaken(%s) = %d, want %d", session.Key(), got, want)
+			}
+		}
+	}
+	for _, session := range view.Joint {
+		if got, want := sessionKeys(view.BackupsFor(session, view.ChildNames)), sessionKeys(result.BackupsFor(session, view.ChildNames)); !slices.Equal(got, want) {
+			t.Errorf("view joint BackupsFor(%s) = %q, want %q", session.Key(), got, want)
+		}
+	}
+
+	// Zoe shares Swim with Alice and holds one of the two spots of the other
+	// Art week, which leaves it a backup for Alice alone but not for both.
+	swim, art, otherArt := sessions[3], sessions[1], sessions[2]
+	if got := view.FriendsSharing("Alice", swim); !slices.Equal(got, []string{"Zoe"}) {
+		t.Errorf("view FriendsSharing(Alice, Swim) = %q, want [Zoe]", got)
+	}
+	if got := sessionKeys(view.BackupsFor(art, []string{"Alice"})); !slices.Equal(got, []string{otherArt.Key()}) {
+		t.Errorf("view BackupsFor(Art, Alice) = %q, want the other Art week", got)
+	}
+	if got := view.BackupsFor(art, []string{"Alice", "Peter"}); len(got) != 0 {
+		t.Errorf("view BackupsFor(Art, Alice and Peter) = %q, want none", sessionKeys(got))
+	}
+}
@@ -0,0 +1,105 @@
+// planner/limits.go
+package planner
+
+import "time"
+
+// withinLimits checks the child's load limits with the candidate added: camp
+// minutes per day and per week, consecutive camp weeks and camp-free weeks
+// left in the summer.
+func (plan *Plan) withinLimits(candidate Session) bool {
+	child := plan.Child
+	if child.MaxMinutesPerDay > 0 || child.MaxMinutesPerWeek > 0 {
+		minutesByDate := plan.minutesByDate(candidate)
+		if child.MaxMinutesPerDay > 0 {
+			for _, date := range candidate.MeetingDates() {
+				if minutesByDate[date] > child.MaxMinutesPerDay {
+					return false
+				}
+			}
+		}
+		if child.MaxMinutesPerWeek > 0 {
+			minutesByWeek := map[time.Time]int{}
+			for date, minutes := range minutesByDate {
+				minutesByWeek[WeekStartOf(date)] += minutes
+			}
+			for weekStart := range candidate.weeks() {
+				if minutesByWeek[weekStart] > child.MaxMinutesPerWeek {
+					return false
+				}
+			}
+		}
+	}
+	if child.MaxConsecutiveWeeks > 0 || child.MinFreeWeeks > 0 {
+		campWeeks := plan.campWeeks(candidate)
+		if child.MaxConsecutiveWeeks > 0 && longestWeekRun(campWeeks) > child.MaxConsecutiveWeeks {
+			return false
+		}
+		if child.MinFreeWeeks > 0 && len(plan.summerWeeks) > 0 {
+			freeWeeks := 0
+			for _, weekStart := range plan.summerWeeks {
+				if _, busy := campWeeks[weekStart]; !busy {
+					freeWeeks++
+				}
+			}
+			if freeWeeks < child.MinFreeWeeks {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// minutesByDate sums the camp minutes of every meeting date, candidate included.
+func (plan *Plan) minutesByDate(candidate Session) map[time.Time]int {
+	minutesByDate := map[time.Time]int{}
+	for _, session := range append(append([]Session(nil), plan.Sessions...), candidate) {
+		minutes := session.EndClockMinutes() - session.StartClockMinutes()
+		for _, date := range session.MeetingDates() {
+			minutesByDate[date] += minutes
+		}
+	}
+	return minutesByDate
+}
+
+// campWeeks collects the weeks with any camp, candidate included.
+func (plan *Plan) campWeeks(candidate Session) map[time.Time]struct{} {
+	weeks := candidate.weeks()
+	for _, session := range plan.Sessions {
+		for weekStart := range session.weeks() {
+			weeks[weekStart] = struct{}{}
+		}
+	}
+	return weeks
+}
+
+// longestWeekRun is the most back-to-back weeks in the set.
+func longestWeekRun(weeks map[time.Time]struct{}) int {
+	longest := 0
+	for weekStart := range weeks {
+		if _, continues := weeks[weekStart.AddDate(0, 0, -7)]; continues {
+			continue
+		}
+		run := 1
+		for next := weekStart.AddDate(0, 0, 7); ; next = next.AddDate(0, 0, 7) {
+			if _, busy := weeks[next]; !busy {
+				break
+			}
+			run++
+		}
+		longest = max(longest, run)
+	}
+	return longest
+}
+
+// SummerWeeks lists the start of every week from the first to the last session date.
+func SummerWeeks(sessions []Session) []time.Time {
+	firstDate, lastDate, known := SummerDateRange(sessions)
+	if !known {
+		return nil
+	}
+	var weeks []time.Time
+	for weekStart := WeekStartOf(firstDate); !weekStart.After(lastDate); weekStart = weekStart.AddDate(0, 0, 7) {
+		weeks = append(weeks, weekStart)
+	}
+	return weeks
+}
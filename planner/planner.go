@@ -0,0 +1,664 @@
+// planner/planner.go
+package planner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultBufferMinutes is the gap kept between two sessions of the same child.
+	DefaultBufferMinutes = 120
+	// DefaultTogethernessBonus is added for every extra sibling sharing a session.
+	DefaultTogethernessBonus = 1
+	siblingSeparatorLiteral  = ", "
+)
+
+// Solver names a planning algorithm.
+type Solver string
+
+// SolverGreedy commits the best (session, sibling group) pair each round.
+const SolverGreedy Solver = "greedy"
+
+// Constraint is an extra rule a candidate session must pass for a child
+// before the planner adds it to that child's plan.
+type Constraint interface {
+	Allows(childName string, plan *Plan, candidate Session) bool
+}
+
+// ConstraintFunc adapts a function to the Constraint interface.
+type ConstraintFunc func(childName string, plan *Plan, candidate Session) bool
+
+// Allows calls the function.
+func (constraint ConstraintFunc) Allows(childName string, plan *Plan, candidate Session) bool {
+	return constraint(childName, plan, candidate)
+}
+
+// Options tunes how the planner ranks and accepts sessions. Per-child
+// coverage windows, blackouts and weekly limits live on Child.
+type Options struct {
+	BufferMinutes         int
+	TogethernessBonus     int
+	BlackoutToleranceDays int
+	// DriversByWeekday is the number of drivers per weekday abbreviation;
+	// unlisted days are not checked unless DriverCalendars are given.
+	DriversByWeekday map[string]int
+	// TravelMinutesByLocation holds one-way minutes from home per facility.
+	TravelMinutesByLocation map[string]int
+	// DriverCalendars are each driver's busy windows, one slice per driver.
+	DriverCalendars [][]WeeklyWindow
+	Solver          Solver
+	Constraints     []Constraint
+	// Backups is how many fallbacks to rank for each chosen session; 0 disables them.
+	Backups int
+	// SuccessProbabilities switches to expected-value planning: every gain is
+	// weighted by the chance registration succeeds, and sessions in any state
+	// with a non-zero chance (waitlists included) become candidates.
+	SuccessProbabilities map[AvailabilityState]float64
+	// Rules set repeat limits, shared quotas, prerequisites and bundles of activities.
+	Rules Rules
+	// DiversityBonus is added for each category a child gets a first session of.
+	DiversityBonus int
+	// OverbookSpots lets a session take more children than its spots left;
+	// such sessions are flagged by Result.Overbooked instead of capped.
+	OverbookSpots bool
+	// Fairness balances siblings: FairnessMaxMin ranks, after covered
+	// minutes, the session of the sibling with the smallest share of wishes
+	// fulfilled first.
+	Fairness Fairness
+	// MaxScoreGap, when positive, stops a child from taking a session while
+	// their priority score is more than this ahead of a sibling who can
+	// still be placed.
+	MaxScoreGap int
+	// FriendBonus is added for every pair of friends sharing a session.
+	FriendBonus int
+}
+
+// DefaultOptions returns the options the command line uses without flags.
+func DefaultOptions() Options {
+	return Options{
+		BufferMinutes:     DefaultBufferMinutes,
+		TogethernessBonus: DefaultTogethernessBonus,
+		FriendBonus:       DefaultFriendBonus,
+		Solver:            SolverGreedy,
+		Backups:           DefaultBackups,
+	}
+}
+
+// Planner assigns sessions to children. It is safe for concurrent use.
+type Planner struct {
+	options Options
+}
+
+// New returns a planner with the given options.
+func New(options Options) *Planner {
+	return &Planner{options: options}
+}
+
+// Options returns the planner's options.
+func (planner *Planner) Options() Options {
+	return planner.options
+}
+
+// Plan is one child's chosen sessions.
+type Plan struct {
+	Child    Child
+	Sessions []Session
+	// MissedDates lists, by Session.Key, the meeting dates a chosen session
+	// loses to the child's blackouts.
+	MissedDates map[string][]time.Time
+	// Backups ranks, by Session.Key, the sessions that could replace a chosen
+	// one if it fills before registering.
+	Backups               map[string][]Session
+	enrolledActivitiesSet map[string]struct{}
+	bufferMinutes         int
+	blackoutToleranceDays int
+	summerWeeks           []time.Time
+	rules                 Rules
+}
+
+// Result is a finished plan for the whole family, or for every household
+// planned together; see ForHousehold.
+type Result struct {
+	Plans map[string]*Plan
+	// ChildNames lists the planned children, sorted.
+	ChildNames []string
+	// Households are the families planned, a single one unless Preferences name several.
+	Households []Household
+	// Joint holds the sessions every child attends, by start date.
+	Joint []Session
+	// Rejections explain sessions the drivers could not make.
+	Rejections []string
+	// Score is the objective the planner maximised.
+	Score int
+	// Unmet lists "child: activity" for every Must activity left out.
+	Unmet []string
+	// ExpectedScore weighs each session's share of Score by its success probability.
+	ExpectedScore float64
+	// Satisfaction is, per child, how many of their wishes the plan fulfils.
+	Satisfaction map[string]ChildSatisfaction
+	options      Options
+	preferences  Preferences
+	friends      map[string]map[string]struct{}
+	// attendees lists, by Session.Key, the children of every household
+	// attending, so a household view still counts spots and finds friends.
+	attendees map[string][]string
+}
+
+// Plan assigns sessions to children under a single objective: the sum of
+// priority scores plus the togetherness bonus for every extra sibling
+// sharing a session. Each round it commits the (session, sibling subset)
+// with the best marginal gain per child placed, so a sibling joining a
+// session already chosen for another child can outrank their own
+// higher-priority pick. With coverage windows, covered minutes rank first,
+// so a morning and an afternoon session can together fill a working day.
+// With SuccessProbabilities, gains and covered minutes are expected values.
+// With several Households, only siblings earn the togetherness bonus, friends
+// earn the friend bonus instead, and each household keeps to its own budget
+// and drivers.
+// Sessions filling a child's category minimum or completing a bundle gain as
+// much as a Must pick.
+// Unmet Must activities do not fail the call; see Result.RequirementsError.
+func (planner *Planner) Plan(ctx context.Context, sessions []Session, preferences Preferences) (*Result, error) {
+	options := planner.options
+	switch options.Solver {
+	case emptyLiteral, SolverGreedy:
+	default:
+		return nil, fmt.Errorf("unknown solver %q", options.Solver)
+	}
+	switch options.Fairness {
+	case FairnessNone, FairnessMaxMin:
+	default:
+		return nil, fmt.Errorf("unknown fairness %q", options.Fairness)
+	}
+	childNames := preferences.ChildNames()
+	for index := 1; index < len(childNames); index++ {
+		if childNames[index] == childNames[index-1] {
+			return nil, fmt.Errorf("duplicate child %q", childNames[index])
+		}
+	}
+	if householdError := validateHouseholds(preferences, childNames); householdError != nil {
+		return nil, householdError
+	}
+
+	// A bundle a child could only partly get is dropped for that child and
+	// the family re-planned, until every bundle is whole or left out.
+	planned := preferences
+	var bundleRejections []string
+	for {
+		result, planError := planner.plan(ctx, sessions, planned, childNames)
+		if planError != nil {
+			return nil, planError
+		}
+		dropped := false
+		for _, childName := range childNames {
+			for _, bundle := range result.Plans[childName].incompleteBundles() {
+				planned = planned.withoutActivities(childName, bundle)
+				bundleRejections = append(bundleRejections, bundleRejection(childName, bundle))
+				dropped = true
+			}
+		}
+		if !dropped {
+			result.Rejections = append(bundleRejections, result.Rejections...)
+			result.Unmet = unmetRequirements(result.Plans, preferences)
+			result.Satisfaction = satisfactions(result.Plans, sessions, preferences, options)
+			result.preferences = preferences
+			return result, nil
+		}
+	}
+}
+
+// plan is one greedy pass over the candidates of the given preferences.
+func (planner *Planner) plan(ctx context.Context, sessions []Session, preferences Preferences, childNames []string) (*Result, error) {
+	options := planner.options
+	summerWeeks := SummerWeeks(sessions)
+	households := preferences.households()
+	householdByChild := householdIndex(households)
+	friendsByChild := friendsOf(preferences)
+	plansByChild := map[string]*Plan{}
+	for _, child := range preferences.Children {
+		plansByChild[child.Name] = &Plan{
+			Child:                 child,
+			MissedDates:           map[string][]time.Time{},
+			enrolledActivitiesSet: map[string]struct{}{},
+			bufferMinutes:         options.BufferMinutes,
+			blackoutToleranceDays: options.BlackoutToleranceDays,
+			summerWeeks:           summerWeeks,
+			rules:                 options.Rules,
+		}
+	}
+
+	type groupCandidate struct {
+		sessionInstance Session
+		childNames      []string
+		household       int
+		priorityScore   int
+		coveredMinutes  int
+		successPercent  int
+	}
+
+	var candidates []groupCandidate
+	for _, session := range sessions {
+		if !options.eligible(session) {
+			continue
+		}
+		for householdIndex, household := range households {
+			var interestedChildren []string
+			for _, childName := range household.ChildNames {
+				if preferences.Wants(childName, session) {
+					interestedChildren = append(interestedChildren, childName)
+				}
+			}
+			for subsetMask := 1; subsetMask < 1<<len(interestedChildren); subsetMask++ {
+				candidate := groupCandidate{sessionInstance: session, household: householdIndex, successPercent: options.successPercent(session)}
+				for childIndex, childName := range interestedChildren {
+					if subsetMask&(1<<childIndex) == 0 {
+						continue
+					}
+					candidate.childNames = append(candidate.childNames, childName)
+					candidate.priorityScore += preferences.ScoreOf(childName, session)
+					candidate.coveredMinutes += CoveredMinutes(session, plansByChild[childName].Child.Coverage) * candidate.successPercent
+				}
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+
+	wishesByChild := wishes(sessions, preferences, options)
+	wishedByChild, fulfilledByChild, scoreByChild := map[string]int{}, map[string]int{}, map[string]int{}
+	for childName, wished := range wishesByChild {
+		for _, priorityValue := range wished {
+			wishedByChild[childName] += wishScore(preferences.Scale, priorityValue)
+		}
+	}
+	attendeesBySession := map[string][]string{}
+	var rejections []string
+	rejectedCandidates := map[int]struct{}{}
+	togetherGain := func(sessionID string, household int, joiningCount int) int {
+		alreadyAttending := 0
+		for _, attendee := range attendeesBySession[sessionID] {
+			if householdByChild[attendee] == household {
+				alreadyAttending++
+			}
+		}
+		return options.TogethernessBonus * (max(alreadyAttending+joiningCount-1, 0) - max(alreadyAttending-1, 0))
+	}
+	friendGain := func(sessionID string, joining []string) int {
+		attending := attendeesBySession[sessionID]
+		return options.FriendBonus * (friendPairs(friendsByChild, append(append([]string(nil), attending...), joining...)) - friendPairs(friendsByChild, attending))
+	}
+	spentByHousehold := make([]float64, len(households))
+
+	for {
+		if contextError := ctx.Err(); contextError != nil {
+			return nil, contextError
+		}
+		type feasibleCandidate struct {
+			index int
+			gain  int
+		}
+		var feasible []feasibleCandidate
+		growing := map[string]struct{}{}
+		for candidateIndex, candidate := range candidates {
+			if _, rejected := rejectedCandidates[candidateIndex]; rejected {
+				continue
+			}
+			groupFits := true
+			for _, childName := range candidate.childNames {
+				if !plansByChild[childName].fits(candidate.sessionInstance) || !options.constraintsAllow(childName, plansByChild[childName], candidate.sessionInstance) {
+					groupFits = false
+					break
+				}
+			}
+			if !groupFits {
+				continue
+			}
+			attending := attendeesBySession[candidate.sessionInstance.Key()]
+			if !options.OverbookSpots && !candidate.sessionInstance.hasRoomFor(len(attending)+len(candidate.childNames)) {
+				rejectedCandidates[candidateIndex] = struct{}{}
+				if len(candidate.childNames) == 1 {
+					spots, _ := candidate.sessionInstance.SpotsLeft()
+					rejections = append(rejections, fmt.Sprintf("%s: %s %s has %d spots left, taken by %s",
+						candidate.childNames[0], candidate.sessionInstance.ActivityName, candidate.sessionInstance.StartDate().Format(dateLayoutISOLiteral),
+						spots, takenBy(attending, households[candidate.household], friendsByChild[candidate.childNames[0]])))
+				}
+				continue
+			}
+			household := households[candidate.household]
+			if !household.withinBudget(spentByHousehold[candidate.household], candidate.sessionInstance, len(candidate.childNames)) {
+				rejectedCandidates[candidateIndex] = struct{}{}
+				if len(candidate.childNames) == 1 {
+					rejections = append(rejections, budgetRejection(candidate.childNames[0], candidate.sessionInstance, household, spentByHousehold[candidate.household]))
+				}
+				continue
+			}
+			if drivable, explanation := driverRunIsFeasible(householdPlans(plansByChild, household), candidate.sessionInstance, candidate.childNames, options); !drivable {
+				rejectedCandidates[candidateIndex] = struct{}{}
+				if len(candidate.childNames) == 1 {
+					rejections = append(rejections, explanation)
+				}
+				continue
+			}
+			gain := candidate.priorityScore + togetherGain(candidate.sessionInstance.Key(), candidate.household, len(candidate.childNames)) + friendGain(candidate.sessionInstance.Key(), candidate.childNames)
+			for _, childName := range candidate.childNames {
+				gain += plansByChild[childName].categoryGain(candidate.sessionInstance, options.DiversityBonus)
+				gain += plansByChild[childName].bundleGain(candidate.sessionInstance)
+			}
+			gain *= candidate.successPercent
+			feasible = append(feasible, feasibleCandidate{index: candidateIndex, gain: gain})
+			for _, childName := range candidate.childNames {
+				growing[childName] = struct{}{}
+			}
+		}
+
+		// leastSatisfied is the smallest share of wished score fulfilled among the children.
+		leastSatisfied := func(names []string) (int, int) {
+			fulfilled, wished := 1, 1
+			for _, childName := range names {
+				if lessSatisfied(fulfilledByChild[childName], wishedByChild[childName], fulfilled, wished) {
+					fulfilled, wished = fulfilledByChild[childName], wishedByChild[childName]
+				}
+			}
+			return fulfilled, wished
+		}
+		// withinGap reports whether no child is over MaxScoreGap ahead of the
+		// lowest-scoring sibling still growing.
+		withinGap := func(names []string) bool {
+			lowest := -1
+			for childName := range growing {
+				if householdByChild[childName] != householdByChild[names[0]] {
+					continue
+				}
+				if lowest < 0 || scoreByChild[childName] < lowest {
+					lowest = scoreByChild[childName]
+				}
+			}
+			for _, childName := range names {
+				if scoreByChild[childName]-lowest > options.MaxScoreGap {
+					return false
+				}
+			}
+			return true
+		}
+		choose := func(capped bool) int {
+			bestIndex, bestGain, bestCount := -1, 0, 1
+			for _, option := range feasible {
+				candidate := candidates[option.index]
+				if capped && !withinGap(candidate.childNames) {
+					continue
+				}
+				if bestIndex >= 0 {
+					best := candidates[bestIndex]
+					candidateFulfilled, candidateWished := leastSatisfied(candidate.childNames)
+					bestFulfilled, bestWished := leastSatisfied(best.childNames)
+					fairer := options.Fairness == FairnessMaxMin && lessSatisfied(candidateFulfilled, candidateWished, bestFulfilled, bestWished)
+					lessFair := options.Fairness == FairnessMaxMin && lessSatisfied(bestFulfilled, bestWished, candidateFulfilled, candidateWished)
+					if candidate.coveredMinutes != best.coveredMinutes {
+						if candidate.coveredMinutes < best.coveredMinutes {
+							continue
+						}
+					} else if fairer || lessFair {
+						if lessFair {
+							continue
+						}
+					} else if option.gain*bestCount != bestGain*len(candidate.childNames) {
+						if option.gain*bestCount < bestGain*len(candidate.childNames) {
+							continue
+						}
+					} else if !candidate.sessionInstance.StartDate().Before(best.sessionInstance.StartDate()) {
+						continue
+					}
+				}
+				bestIndex, bestGain, bestCount = option.index, option.gain, len(candidate.childNames)
+			}
+			return bestIndex
+		}
+		// A gap no candidate can close is not allowed to stall the plan.
+		bestIndex := choose(options.MaxScoreGap > 0)
+		if bestIndex < 0 && options.MaxScoreGap > 0 {
+			bestIndex = choose(false)
+		}
+		if bestIndex < 0 {
+			break
+		}
+
+		chosen := candidates[bestIndex]
+		chosenID := chosen.sessionInstance.Key()
+		for _, childName := range chosen.childNames {
+			if priorityValue, wished := wishesByChild[childName][chosen.sessionInstance.ActivityKey()]; wished && !plansByChild[childName].Enrolled(chosen.sessionInstance.ActivityKey()) {
+				fulfilledByChild[childName] += wishScore(preferences.Scale, priorityValue)
+			}
+			scoreByChild[childName] += preferences.ScoreOf(childName, chosen.sessionInstance)
+			plansByChild[childName].add(chosen.sessionInstance)
+		}
+		attendeesBySession[chosenID] = append(attendeesBySession[chosenID], chosen.childNames...)
+		if cost, known := chosen.sessionInstance.Cost(); known {
+			spentByHousehold[chosen.household] += cost * float64(len(chosen.childNames))
+		}
+	}
+
+	result := &Result{Plans: plansByChild, ChildNames: childNames, Households: households, Rejections: rejections, options: options, preferences: preferences, friends: friendsByChild, attendees: attendeesBySession}
+	for _, session := range sessions {
+		if len(childNames) > 0 && len(attendeesBySession[session.Key()]) == len(childNames) {
+			result.Joint = append(result.Joint, session)
+		}
+	}
+	sort.SliceStable(result.Joint, func(i, j int) bool { return result.Joint[i].StartDate().Before(result.Joint[j].StartDate()) })
+	for _, plan := range plansByChild {
+		sort.SliceStable(plan.Sessions, func(i, j int) bool { return plan.Sessions[i].StartDate().Before(plan.Sessions[j].StartDate()) })
+	}
+	computeBackups(plansByChild, sessions, preferences, options)
+	result.Score, result.ExpectedScore = result.score(preferences, options)
+	result.Satisfaction = satisfactions(plansByChild, sessions, preferences, options)
+	return result, nil
+}
+
+func (options Options) constraintsAllow(childName string, plan *Plan, candidate Session) bool {
+	for _, constraint := range options.Constraints {
+		if !constraint.Allows(childName, plan, candidate) {
+			return false
+		}
+	}
+	return true
+}
+
+// score is the objective Plan maximises: every child's priority score for
+// each session plus the togetherness bonus per extra sibling, the friend
+// bonus per pair of friends and the diversity bonus per category, both as is
+// and weighted by each session's success probability.
+func (result *Result) score(preferences Preferences, options Options) (int, float64) {
+	score, expected := 0, 0.0
+	householdByChild := householdIndex(result.Households)
+	type sessionHousehold struct {
+		key       string
+		household int
+	}
+	siblingsBySession := map[sessionHousehold]int{}
+	attendeesBySession := map[string][]string{}
+	sessionsByKey := map[string]Session{}
+	for _, childName := range result.ChildNames {
+		for _, session := range result.Plans[childName].Sessions {
+			sessionScore := preferences.ScoreOf(childName, session)
+			score += sessionScore
+			expected += float64(sessionScore) * options.SuccessProbability(session)
+			siblingsBySession[sessionHousehold{session.Key(), householdByChild[childName]}]++
+			attendeesBySession[session.Key()] = append(attendeesBySession[session.Key()], childName)
+			sessionsByKey[session.Key()] = session
+		}
+	}
+	for group, siblings := range siblingsBySession {
+		bonus := options.TogethernessBonus * (siblings - 1)
+		score += bonus
+		expected += float64(bonus) * options.SuccessProbability(sessionsByKey[group.key])
+	}
+	for key, attendees := range attendeesBySession {
+		bonus := options.FriendBonus * friendPairs(result.friends, attendees)
+		score += bonus
+		expected += float64(bonus) * options.SuccessProbability(sessionsByKey[key])
+	}
+	for _, childName := range result.ChildNames {
+		diversity, expectedDiversity := result.Plans[childName].diversityScore(options)
+		score += diversity
+		expected += expectedDiversity
+	}
+	return score, expected
+}
+
+// SuccessProbability is the chance registering for the session succeeds under the plan's options.
+func (result *Result) SuccessProbability(session Session) float64 {
+	return result.options.SuccessProbability(session)
+}
+
+// unmetRequirements lists every Must activity a child's plan does not contain
+// and every category minimum it misses.
+func unmetRequirements(plans map[string]*Plan, preferences Preferences) []string {
+	unmet := unmetCategoryMinimums(plans)
+	for activityName, priorityByChild := range preferences.Priorities {
+		for childName, priorityValue := range priorityByChild {
+			if plans[childName] == nil || !preferences.Scale.IsMust(priorityValue) {
+				continue
+			}
+			if _, enrolled := plans[childName].enrolledActivitiesSet[activityName]; !enrolled {
+				unmet = append(unmet, fmt.Sprintf("%s: %s", childName, activityName))
+			}
+		}
+	}
+	sort.Strings(unmet)
+	return unmet
+}
+
+// RequirementsError describes the unmet Must activities, or is nil when every one was scheduled.
+func (result *Result) RequirementsError() error {
+	if len(result.Unmet) == 0 {
+		return nil
+	}
+	return fmt.Errorf("cannot schedule required sessions:\n  %s", strings.Join(result.Unmet, "\n  "))
+}
+
+// IsJoint reports whether every child attends the session.
+func (result *Result) IsJoint(session Session) bool {
+	key := session.Key()
+	for _, joint := range result.Joint {
+		if joint.Key() == key {
+			return true
+		}
+	}
+	return false
+}
+
+// SiblingsSharing lists the other children of the child's household whose plan contains the same session.
+func (result *Result) SiblingsSharing(childName string, session Session) []string {
+	var siblings []string
+	household := result.HouseholdOf(childName)
+	for _, otherName := range result.ChildNames {
+		if otherName != childName && containsString(household.ChildNames, otherName) && result.Plans[otherName].Has(session) {
+			siblings = append(siblings, otherName)
+		}
+	}
+	return siblings
+}
+
+// Attendees lists the result's children whose plan contains the session; on a
+// household view, only that household's.
+func (result *Result) Attendees(session Session) []string {
+	var attendees []string
+	for _, childName := range result.ChildNames {
+		if result.Plans[childName].Has(session) {
+			attendees = append(attendees, childName)
+		}
+	}
+	return attendees
+}
+
+// SpotsTaken counts the children of every household planned into the session.
+func (result *Result) SpotsTaken(session Session) int {
+	return len(result.attendees[session.Key()])
+}
+
+// Overbooked reports whether more children attend the session than it had spots left.
+func (result *Result) Overbooked(session Session) bool {
+	return !session.hasRoomFor(result.SpotsTaken(session))
+}
+
+// JointMissedDates merges the blacked-out dates every child loses in a joint session.
+func (result *Result) JointMissedDates(session Session) []time.Time {
+	seen := map[time.Time]struct{}{}
+	var missedDates []time.Time
+	for _, childName := range result.ChildNames {
+		for _, date := range result.Plans[childName].MissedDates[session.Key()] {
+			if _, duplicate := seen[date]; !duplicate {
+				seen[date] = struct{}{}
+				missedDates = append(missedDates, date)
+			}
+		}
+	}
+	sort.Slice(missedDates, func(i, j int) bool { return missedDates[i].Before(missedDates[j]) })
+	return missedDates
+}
+
+// Has reports whether the plan contains the session.
+func (plan *Plan) Has(session Session) bool {
+	key := session.Key()
+	for _, scheduled := range plan.Sessions {
+		if scheduled.Key() == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Enrolled reports whether the plan already holds a session of the activity.
+func (plan *Plan) Enrolled(activityKey string) bool {
+	_, enrolled := plan.enrolledActivitiesSet[activityKey]
+	return enrolled
+}
+
+func (plan *Plan) fits(candidate Session) bool {
+	if !plan.allowsActivity(candidate) {
+		return false
+	}
+	if len(BlackedOutMeetingDates(candidate, plan.Child.Blackouts)) > plan.blackoutToleranceDays {
+		return false
+	}
+	if plan.Child.MaxSessionsPerWeek > 0 && plan.BusiestWeekLoad(candidate) >= plan.Child.MaxSessionsPerWeek {
+		return false
+	}
+	if !plan.withinLimits(candidate) || !plan.withinCategoryLimits(candidate) {
+		return false
+	}
+	if plan.clashesWithCommitment(candidate) {
+		return false
+	}
+	for _, existing := range plan.Sessions {
+		if sessionsOverlap(existing, candidate, plan.bufferMinutes) {
+			return false
+		}
+	}
+	return true
+}
+
+// BusiestWeekLoad counts, over the weeks the candidate meets, the most sessions already planned in one week.
+func (plan *Plan) BusiestWeekLoad(candidate Session) int {
+	busiest := 0
+	for weekStart := range candidate.weeks() {
+		load := 0
+		for _, scheduled := range plan.Sessions {
+			if _, sameWeek := scheduled.weeks()[weekStart]; sameWeek {
+				load++
+			}
+		}
+		busiest = max(busiest, load)
+	}
+	return busiest
+}
+
+func (plan *Plan) add(session Session) {
+	plan.Sessions = append(plan.Sessions, session)
+	plan.enrolledActivitiesSet[session.ActivityKey()] = struct{}{}
+	if missedDates := BlackedOutMeetingDates(session, plan.Child.Blackouts); len(missedDates) > 0 {
+		plan.MissedDates[session.Key()] = missedDates
+	}
+}
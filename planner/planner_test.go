@@ -0,0 +1,670 @@
+// planner/planner_test.go
+package planner
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+// testSession is a Monday-to-Friday session of the week starting monday, e.g. "2025-06-16".
+func testSession(title, monday, startTime, endTime string) Session {
+	start, _ := time.Parse(dateLayoutISOLiteral, monday)
+	return Session{
+		ActivityName:         title,
+		StartDateUnixSeconds: start.Unix(),
+		EndDateUnixSeconds:   start.AddDate(0, 0, 4).Unix(),
+		DaysOfWeek:           []string{"Mon", "Tue", "Wed", "Thu", "Fri"},
+		StartTimeMilitary:    startTime,
+		EndTimeMilitary:      endTime,
+	}
+}
+
+// withLocation returns the session held at the facility.
+func withLocation(session Session, location string) Session {
+	session.Location = location
+	return session
+}
+
+// withAvailability returns the session with the scraped availability text, e.g. "2 spaces left".
+func withAvailability(session Session, availabilityText string) Session {
+	session.AvailabilityText = availabilityText
+	return session
+}
+
+// withPrice returns the session with the scraped price text, e.g. "$150.00".
+func withPrice(session Session, price string) Session {
+	session.Price = price
+	return session
+}
+
+// withCategories returns the session tagged with the categories.
+func withCategories(session Session, categories ...string) Session {
+	session.Categories = categories
+	return session
+}
+
+// testPreferences gives every child age 8 and the default scale.
+func testPreferences(priorities map[string]map[string]string, childNames ...string) Preferences {
+	preferences := Preferences{Priorities: priorities, Scale: DefaultPriorityScale()}
+	for _, childName := range childNames {
+		preferences.Children = append(preferences.Children, Child{Name: childName, Age: 8})
+	}
+	return preferences
+}
+
+// withChildren applies adjust to every child of the preferences.
+func withChildren(preferences Preferences, adjust func(child *Child)) Preferences {
+	for childIndex := range preferences.Children {
+		adjust(&preferences.Children[childIndex])
+	}
+	return preferences
+}
+
+// testWindow is a Monday-to-Friday window over the week starting monday.
+func testWindow(monday, startTime, endTime string) WeeklyWindow {
+	window, _ := ParseWeeklyWindow("Mon-Fri", startTime, endTime, monday, monday)
+	window.ToDate = window.FromDate.AddDate(0, 0, 4)
+	return window
+}
+
+// testBlackout is a blackout from one ISO date to another, inclusive.
+func testBlackout(from, to string) Blackout {
+	fromDate, _ := time.Parse(dateLayoutISOLiteral, from)
+	toDate, _ := time.Parse(dateLayoutISOLiteral, to)
+	return Blackout{FromDate: fromDate, ToDate: toDate}
+}
+
+// testCommitment is a commitment on days, e.g. "Tue,Thu", from one ISO date to another.
+func testCommitment(days, startTime, endTime, from, to string) Commitment {
+	window, _ := ParseWeeklyWindow(days, startTime, endTime, from, to)
+	return Commitment{WeeklyWindow: window}
+}
+
+// inHouseholds splits the preferences' children into households.
+func inHouseholds(preferences Preferences, households ...Household) Preferences {
+	preferences.Households = households
+	return preferences
+}
+
+// withFriends makes friendName a friend of childName.
+func withFriends(preferences Preferences, childName, friendName string) Preferences {
+	return withChildren(preferences, func(child *Child) {
+		if child.Name == childName {
+			child.Friends = append(child.Friends, friendName)
+		}
+	})
+}
+
+// plannedSessions lists each child's sessions as "Title 2025-06-16".
+func plannedSessions(result *Result) map[string][]string {
+	planned := map[string][]string{}
+	for childName, plan := range result.Plans {
+		for _, session := range plan.Sessions {
+			planned[childName] = append(planned[childName], session.ActivityName+" "+session.StartDate().UTC().Format(dateLayoutISOLiteral))
+		}
+	}
+	return planned
+}
+
+func TestPlan(t *testing.T) {
+	const week = "2025-06-16"
+	tests := []struct {
+		name           string
+		sessions       []Session
+		preferences    Preferences
+		options        func(*Options)
+		want           map[string][]string
+		wantUnmet      []string
+		wantRejections []string
+	}{
+		{
+			name: "overlapping sessions keep the higher priority",
+			sessions: []Session{
+				testSession("Art", week, "09:00", "12:00"),
+				testSession("Swim", week, "10:00", "15:00"),
+			},
+			preferences: testPreferences(map[string]map[string]string{
+				"Art":  {"Alice": PriorityMedium},
+				"Swim": {"Alice": PriorityHigh},
+			}, "Alice"),
+			want: map[string][]string{"Alice": {"Swim " + week}},
+		},
+		{
+			name: "the buffer keeps back-to-back sessions apart",
+			sessions: []Session{
+				testSession("Art", week, "09:00", "12:00"),
+				testSession("Swim", week, "13:00", "15:00"),
+			},
+			preferences: testPreferences(map[string]map[string]string{
+				"Art":  {"Alice": PriorityHigh},
+				"Swim": {"Alice": PriorityMedium},
+			}, "Alice"),
+			want: map[string][]string{"Alice": {"Art " + week}},
+		},
+		{
+			name: "sessions clear of the buffer both fit",
+			sessions: []Session{
+				testSession("Art", week, "09:00", "12:00"),
+				testSession("Swim", week, "13:00", "15:00"),
+			},
+			preferences: testPreferences(map[string]map[string]string{
+				"Art":  {"Alice": PriorityHigh},
+				"Swim": {"Alice": PriorityMedium},
+			}, "Alice"),
+			options: func(options *Options) { options.BufferMinutes = 30 },
+			want:    map[string][]string{"Alice": {"Art " + week, "Swim " + week}},
+		},
+		{
+			name: "coverage ranks covered minutes before priority",
+			sessions: []Session{
+				testSession("Art", week, "09:00", "12:00"),
+				testSession("Day Camp", week, "09:00", "15:00"),
+			},
+			preferences: withChildren(testPreferences(map[string]map[string]string{
+				"Art":      {"Alice": PriorityHigh},
+				"Day Camp": {"Alice": PriorityLow},
+			}, "Alice"), func(child *Child) { child.Coverage = []WeeklyWindow{testWindow(week, "09:00", "15:00")} }),
+			want: map[string][]string{"Alice": {"Day Camp " + week}},
+		},
+		{
+			name: "a morning and an afternoon session fill a working day together",
+			sessions: []Session{
+				testSession("Art", week, "09:00", "12:00"),
+				testSession("Swim", week, "12:00", "15:00"),
+				testSession("Chess", week, "10:00", "12:30"),
+			},
+			preferences: withChildren(testPreferences(map[string]map[string]string{
+				"Art":   {"Alice": PriorityLow},
+				"Swim":  {"Alice": PriorityLow},
+				"Chess": {"Alice": PriorityHigh},
+			}, "Alice"), func(child *Child) { child.Coverage = []WeeklyWindow{testWindow(week, "09:00", "15:00")} }),
+			options: func(options *Options) { options.BufferMinutes = 0 },
+			want:    map[string][]string{"Alice": {"Art " + week, "Swim " + week}},
+		},
+		{
+			name: "a blackout day keeps a child out of the session",
+			sessions: []Session{
+				testSession("Art", week, "09:00", "12:00"),
+				testSession("Swim", "2025-06-23", "09:00", "12:00"),
+			},
+			preferences: withChildren(testPreferences(map[string]map[string]string{
+				"Art":  {"Alice": PriorityHigh, "Bob": PriorityHigh},
+				"Swim": {"Alice": PriorityLow, "Bob": PriorityLow},
+			}, "Alice", "Bob"), func(child *Child) {
+				if child.Name == "Alice" {
+					child.Blackouts = []Blackout{testBlackout("2025-06-18", "2025-06-18")}
+				}
+			}),
+			want: map[string][]string{
+				"Alice": {"Swim 2025-06-23"},
+				"Bob":   {"Art " + week, "Swim 2025-06-23"},
+			},
+		},
+		{
+			name: "the blackout tolerance lets a session lose that many days",
+			sessions: []Session{
+				testSession("Art", week, "09:00", "12:00"),
+			},
+			preferences: withChildren(testPreferences(map[string]map[string]string{
+				"Art": {"Alice": PriorityHigh},
+			}, "Alice"), func(child *Child) { child.Blackouts = []Blackout{testBlackout("2025-06-19", "2025-06-22")} }),
+			options: func(options *Options) { options.BlackoutToleranceDays = 2 },
+			want:    map[string][]string{"Alice": {"Art " + week}},
+		},
+		{
+			name: "a blackout longer than the tolerance still excludes the session",
+			sessions: []Session{
+				testSession("Art", week, "09:00", "12:00"),
+			},
+			preferences: withChildren(testPreferences(map[string]map[string]string{
+				"Art": {"Alice": PriorityHigh},
+			}, "Alice"), func(child *Child) { child.Blackouts = []Blackout{testBlackout("2025-06-18", "2025-06-22")} }),
+			options: func(options *Options) { options.BlackoutToleranceDays = 2 },
+			want:    map[string][]string{},
+		},
+		{
+			name: "without a togetherness bonus each child takes their favourite",
+			sessions: []Session{
+				testSession("Art", week, "09:00", "12:00"),
+				testSession("Swim", week, "09:00", "12:00"),
+			},
+			preferences: testPreferences(map[string]map[string]string{
+				"Art":  {"Alice": PriorityHigh},
+				"Swim": {"Alice": PriorityMedium, "Bob": PriorityHigh},
+			}, "Alice", "Bob"),
+			options: func(options *Options) { options.TogethernessBonus = 0 },
+			want:    map[string][]string{"Alice": {"Art " + week}, "Bob": {"Swim " + week}},
+		},
+		{
+			name: "the togetherness bonus outweighs a higher priority apart",
+			sessions: []Session{
+				testSession("Art", week, "09:00", "12:00"),
+				testSession("Swim", week, "09:00", "12:00"),
+			},
+			preferences: testPreferences(map[string]map[string]string{
+				"Art":  {"Alice": PriorityHigh},
+				"Swim": {"Alice": PriorityMedium, "Bob": PriorityHigh},
+			}, "Alice", "Bob"),
+			options: func(options *Options) { options.TogethernessBonus = 4 },
+			want:    map[string][]string{"Alice": {"Swim " + week}, "Bob": {"Swim " + week}},
+		},
+		{
+			name: "one driver cannot drop two children at two places at once",
+			sessions: []Session{
+				withLocation(testSession("Art", week, "09:00", "12:00"), "Studio"),
+				withLocation(testSession("Swim", week, "09:00", "12:00"), "Pool"),
+			},
+			preferences: testPreferences(map[string]map[string]string{
+				"Art":  {"Alice": PriorityHigh},
+				"Swim": {"Bob": PriorityMedium},
+			}, "Alice", "Bob"),
+			options: func(options *Options) { options.DriversByWeekday = map[string]int{"Mon": 1} },
+			want:    map[string][]string{"Alice": {"Art " + week}},
+			wantRejections: []string{
+				"Bob: Swim drop-off at 09:00, Pool on Mon 2025-06-16 has no free driver (1 available)",
+			},
+		},
+		{
+			name: "a second driver takes the other drop-off",
+			sessions: []Session{
+				withLocation(testSession("Art", week, "09:00", "12:00"), "Studio"),
+				withLocation(testSession("Swim", week, "09:00", "12:00"), "Pool"),
+			},
+			preferences: testPreferences(map[string]map[string]string{
+				"Art":  {"Alice": PriorityHigh},
+				"Swim": {"Bob": PriorityMedium},
+			}, "Alice", "Bob"),
+			options: func(options *Options) { options.DriversByWeekday = map[string]int{"Mon": 2} },
+			want:    map[string][]string{"Alice": {"Art " + week}, "Bob": {"Swim " + week}},
+		},
+		{
+			name: "siblings at the same place share one driver",
+			sessions: []Session{
+				withLocation(testSession("Art", week, "09:00", "12:00"), "Centre"),
+				withLocation(testSession("Swim", week, "09:00", "12:00"), "Centre"),
+			},
+			preferences: testPreferences(map[string]map[string]string{
+				"Art":  {"Alice": PriorityHigh},
+				"Swim": {"Bob": PriorityMedium},
+			}, "Alice", "Bob"),
+			options: func(options *Options) { options.DriversByWeekday = map[string]int{"Mon": 1} },
+			want:    map[string][]string{"Alice": {"Art " + week}, "Bob": {"Swim " + week}},
+		},
+		{
+			name: "a Must activity beats any wish it overlaps",
+			sessions: []Session{
+				testSession("Art", week, "09:00", "12:00"),
+				testSession("Chess", week, "09:00", "12:00"),
+			},
+			preferences: testPreferences(map[string]map[string]string{
+				"Art":   {"Alice": PriorityHigh},
+				"Chess": {"Alice": PriorityMust},
+			}, "Alice"),
+			want: map[string][]string{"Alice": {"Chess " + week}},
+		},
+		{
+			name: "a Must activity that cannot fit is unmet",
+			sessions: []Session{
+				testSession("Chess", week, "09:00", "12:00"),
+				testSession("Piano", week, "10:00", "11:00"),
+			},
+			preferences: testPreferences(map[string]map[string]string{
+				"Chess": {"Alice": PriorityMust},
+				"Piano": {"Alice": PriorityMust},
+			}, "Alice"),
+			want:      map[string][]string{"Alice": {"Chess " + week}},
+			wantUnmet: []string{"Alice: Piano"},
+		},
+		{
+			name: "a session takes no more children than its spots left",
+			sessions: []Session{
+				withAvailability(testSession("Art", week, "09:00", "12:00"), "1 space left"),
+			},
+			preferences: testPreferences(map[string]map[string]string{
+				"Art": {"Alice": PriorityHigh, "Bob": PriorityHigh},
+			}, "Alice", "Bob"),
+			want:           map[string][]string{"Alice": {"Art " + week}},
+			wantRejections: []string{"Bob: Art 2025-06-16 has 1 spots left, taken by Alice"},
+		},
+		{
+			name: "overbooking lets every sibling in",
+			sessions: []Session{
+				withAvailability(testSession("Art", week, "09:00", "12:00"), "1 space left"),
+			},
+			preferences: testPreferences(map[string]map[string]string{
+				"Art": {"Alice": PriorityHigh, "Bob": PriorityHigh},
+			}, "Alice", "Bob"),
+			options: func(options *Options) { options.OverbookSpots = true },
+			want:    map[string][]string{"Alice": {"Art " + week}, "Bob": {"Art " + week}},
+		},
+		{
+			name: "a daily minute limit leaves out the session that exceeds it",
+			sessions: []Session{
+				testSession("Art", week, "09:00", "12:00"),
+				testSession("Swim", week, "14:30", "16:30"),
+			},
+			preferences: withChildren(testPreferences(map[string]map[string]string{
+				"Art":  {"Alice": PriorityHigh},
+				"Swim": {"Alice": PriorityMedium},
+			}, "Alice"), func(child *Child) { child.MaxMinutesPerDay = 240 }),
+			want: map[string][]string{"Alice": {"Art " + week}},
+		},
+		{
+			name: "a consecutive week limit breaks the run",
+			sessions: []Session{
+				testSession("Art", week, "09:00", "12:00"),
+				testSession("Swim", "2025-06-23", "09:00", "12:00"),
+				testSession("Chess", "2025-06-30", "09:00", "12:00"),
+			},
+			preferences: withChildren(testPreferences(map[string]map[string]string{
+				"Art":   {"Alice": PriorityHigh},
+				"Swim":  {"Alice": PriorityMedium},
+				"Chess": {"Alice": PriorityLow},
+			}, "Alice"), func(child *Child) { child.MaxConsecutiveWeeks = 2 }),
+			want: map[string][]string{"Alice": {"Art " + week, "Swim 2025-06-23"}},
+		},
+		{
+			name: "free weeks are kept out of the summer",
+			sessions: []Session{
+				testSession("Art", week, "09:00", "12:00"),
+				testSession("Swim", "2025-06-23", "09:00", "12:00"),
+			},
+			preferences: withChildren(testPreferences(map[string]map[string]string{
+				"Art":  {"Alice": PriorityMedium},
+				"Swim": {"Alice": PriorityHigh},
+			}, "Alice"), func(child *Child) { child.MinFreeWeeks = 1 }),
+			want: map[string][]string{"Alice": {"Swim 2025-06-23"}},
+		},
+		{
+			name: "a category maximum caps the child's sessions in it",
+			sessions: []Session{
+				withCategories(testSession("Swim", week, "09:00", "12:00"), "sports"),
+				withCategories(testSession("Soccer", "2025-06-23", "09:00", "12:00"), "sports"),
+			},
+			preferences: withChildren(testPreferences(map[string]map[string]string{
+				"Swim":   {"Alice": PriorityHigh},
+				"Soccer": {"Alice": PriorityMedium},
+			}, "Alice"), func(child *Child) {
+				child.CategoryLimits = map[string]CategoryLimit{"sports": {MaxSessions: 1}}
+			}),
+			want: map[string][]string{"Alice": {"Swim " + week}},
+		},
+		{
+			name: "a category minimum outranks a higher priority",
+			sessions: []Session{
+				withCategories(testSession("Art", week, "09:00", "12:00"), "arts"),
+				withCategories(testSession("Swim", week, "09:00", "12:00"), "sports"),
+			},
+			preferences: withChildren(testPreferences(map[string]map[string]string{
+				"Art":  {"Alice": PriorityLow},
+				"Swim": {"Alice": PriorityHigh},
+			}, "Alice"), func(child *Child) {
+				child.CategoryLimits = map[string]CategoryLimit{"arts": {MinSessions: 1}}
+			}),
+			want: map[string][]string{"Alice": {"Art " + week}},
+		},
+		{
+			name: "a category minimum that cannot be met is unmet",
+			sessions: []Session{
+				withCategories(testSession("Art", week, "09:00", "12:00"), "arts"),
+			},
+			preferences: withChildren(testPreferences(map[string]map[string]string{
+				"Art": {"Alice": PriorityLow},
+			}, "Alice"), func(child *Child) {
+				child.CategoryLimits = map[string]CategoryLimit{"arts": {MinSessions: 2}}
+			}),
+			want:      map[string][]string{"Alice": {"Art " + week}},
+			wantUnmet: []string{"Alice: at least 2 arts sessions"},
+		},
+		{
+			name: "the diversity bonus favours a category the child has none of",
+			sessions: []Session{
+				withCategories(testSession("Soccer", week, "09:00", "12:00"), "sports"),
+				withCategories(testSession("Swim", "2025-06-23", "09:00", "12:00"), "sports"),
+				withCategories(testSession("Art", "2025-06-23", "09:00", "12:00"), "arts"),
+			},
+			preferences: testPreferences(map[string]map[string]string{
+				"Soccer": {"Alice": PriorityHigh},
+				"Swim":   {"Alice": PriorityMedium},
+				"Art":    {"Alice": PriorityMedium},
+			}, "Alice"),
+			options: func(options *Options) { options.DiversityBonus = 2 },
+			want:    map[string][]string{"Alice": {"Soccer " + week, "Art 2025-06-23"}},
+		},
+		{
+			name: "a child takes one session of an activity by default",
+			sessions: []Session{
+				testSession("Art", week, "09:00", "12:00"),
+				testSession("Art", "2025-06-23", "09:00", "12:00"),
+			},
+			preferences: testPreferences(map[string]map[string]string{
+				"Art": {"Alice": PriorityHigh},
+			}, "Alice"),
+			want: map[string][]string{"Alice": {"Art " + week}},
+		},
+		{
+			name: "max repeats allow more sessions of an activity",
+			sessions: []Session{
+				testSession("Art", week, "09:00", "12:00"),
+				testSession("Art", "2025-06-23", "09:00", "12:00"),
+			},
+			preferences: testPreferences(map[string]map[string]string{
+				"Art": {"Alice": PriorityHigh},
+			}, "Alice"),
+			options: func(options *Options) { options.Rules = Rules{MaxRepeats: map[string]int{"Art": 2}} },
+			want:    map[string][]string{"Alice": {"Art " + week, "Art 2025-06-23"}},
+		},
+		{
+			name: "an activity family shares one quota",
+			sessions: []Session{
+				testSession("Swim", week, "09:00", "12:00"),
+				testSession("Dive", "2025-06-23", "09:00", "12:00"),
+			},
+			preferences: testPreferences(map[string]map[string]string{
+				"Swim": {"Alice": PriorityHigh},
+				"Dive": {"Alice": PriorityMedium},
+			}, "Alice"),
+			options: func(options *Options) {
+				options.Rules = Rules{Families: []ActivityFamily{{Name: "water", Activities: []string{"Swim", "Dive"}}}}
+			},
+			want: map[string][]string{"Alice": {"Swim " + week}},
+		},
+		{
+			name: "a prerequisite must end before the activity starts",
+			sessions: []Session{
+				testSession("Swim 2", week, "09:00", "12:00"),
+				testSession("Swim 1", "2025-06-23", "09:00", "12:00"),
+			},
+			preferences: testPreferences(map[string]map[string]string{
+				"Swim 1": {"Alice": PriorityMedium},
+				"Swim 2": {"Alice": PriorityHigh},
+			}, "Alice"),
+			options: func(options *Options) {
+				options.Rules = Rules{Prerequisites: map[string][]string{"Swim 2": {"Swim 1"}}}
+			},
+			want: map[string][]string{"Alice": {"Swim 1 2025-06-23"}},
+		},
+		{
+			name: "a bundle only partly placed is dropped",
+			sessions: []Session{
+				testSession("Art", week, "09:00", "12:00"),
+				testSession("Clay", "2025-06-23", "09:00", "12:00"),
+				testSession("Chess", "2025-06-23", "09:00", "12:00"),
+			},
+			preferences: testPreferences(map[string]map[string]string{
+				"Art":   {"Alice": PriorityHigh},
+				"Clay":  {"Alice": PriorityHigh},
+				"Chess": {"Alice": PriorityMust},
+			}, "Alice"),
+			options:        func(options *Options) { options.Rules = Rules{Bundles: [][]string{{"Art", "Clay"}}} },
+			want:           map[string][]string{"Alice": {"Chess 2025-06-23"}},
+			wantRejections: []string{"Alice: Art + Clay dropped, cannot take the whole bundle"},
+		},
+		{
+			name: "a commitment overlapping a meeting day keeps the session out",
+			sessions: []Session{
+				testSession("Drama", week, "12:00", "14:00"),
+			},
+			preferences: withChildren(testPreferences(map[string]map[string]string{
+				"Drama": {"Alice": PriorityHigh},
+			}, "Alice"), func(child *Child) {
+				child.Commitments = []Commitment{testCommitment("Tue", "13:00", "14:00", week, "2025-08-29")}
+			}),
+			options: func(options *Options) { options.BufferMinutes = 0 },
+			want:    map[string][]string{},
+		},
+		{
+			name: "the buffer keeps a session clear of a commitment",
+			sessions: []Session{
+				testSession("Art", week, "09:00", "12:00"),
+				testSession("Swim", "2025-06-23", "09:00", "11:00"),
+			},
+			preferences: withChildren(testPreferences(map[string]map[string]string{
+				"Art":  {"Alice": PriorityHigh},
+				"Swim": {"Alice": PriorityLow},
+			}, "Alice"), func(child *Child) {
+				child.Commitments = []Commitment{testCommitment("Wed", "13:00", "14:00", week, "2025-08-29")}
+			}),
+			options: func(options *Options) { options.BufferMinutes = 90 },
+			want:    map[string][]string{"Alice": {"Swim 2025-06-23"}},
+		},
+		{
+			name: "a commitment outside the session's weeks does not clash",
+			sessions: []Session{
+				testSession("Drama", week, "12:00", "14:00"),
+			},
+			preferences: withChildren(testPreferences(map[string]map[string]string{
+				"Drama": {"Alice": PriorityHigh},
+			}, "Alice"), func(child *Child) {
+				child.Commitments = []Commitment{testCommitment("Tue", "13:00", "14:00", "2025-06-23", "2025-08-29")}
+			}),
+			want: map[string][]string{"Alice": {"Drama " + week}},
+		},
+		{
+			name: "without fairness the last spot goes to the first sibling",
+			sessions: []Session{
+				testSession("Clay", week, "09:00", "12:00"),
+				withAvailability(testSession("Art", "2025-06-23", "09:00", "12:00"), "1 space left"),
+			},
+			preferences: testPreferences(map[string]map[string]string{
+				"Clay": {"Alice": PriorityHigh},
+				"Art":  {"Alice": PriorityHigh, "Bob": PriorityHigh},
+			}, "Alice", "Bob"),
+			want:           map[string][]string{"Alice": {"Clay " + week, "Art 2025-06-23"}},
+			wantRejections: []string{"Bob: Art 2025-06-23 has 1 spots left, taken by Alice"},
+		},
+		{
+			name: "max-min fairness gives the last spot to the less satisfied sibling",
+			sessions: []Session{
+				testSession("Clay", week, "09:00", "12:00"),
+				withAvailability(testSession("Art", "2025-06-23", "09:00", "12:00"), "1 space left"),
+			},
+			preferences: testPreferences(map[string]map[string]string{
+				"Clay": {"Alice": PriorityHigh},
+				"Art":  {"Alice": PriorityHigh, "Bob": PriorityHigh},
+			}, "Alice", "Bob"),
+			options:        func(options *Options) { options.Fairness = FairnessMaxMin },
+			want:           map[string][]string{"Alice": {"Clay " + week}, "Bob": {"Art 2025-06-23"}},
+			wantRejections: []string{"Alice: Art 2025-06-23 has 1 spots left, taken by Bob"},
+		},
+		{
+			name: "a score gap holds back the sibling ahead",
+			sessions: []Session{
+				testSession("Clay", week, "09:00", "12:00"),
+				withAvailability(testSession("Art", "2025-06-23", "09:00", "12:00"), "1 space left"),
+			},
+			preferences: testPreferences(map[string]map[string]string{
+				"Clay": {"Alice": PriorityHigh},
+				"Art":  {"Alice": PriorityHigh, "Bob": PriorityHigh},
+			}, "Alice", "Bob"),
+			options:        func(options *Options) { options.MaxScoreGap = 2 },
+			want:           map[string][]string{"Alice": {"Clay " + week}, "Bob": {"Art 2025-06-23"}},
+			wantRejections: []string{"Alice: Art 2025-06-23 has 1 spots left, taken by Bob"},
+		},
+		{
+			name: "a full session counts other families' children without naming them",
+			sessions: []Session{
+				withAvailability(testSession("Art", week, "09:00", "12:00"), "2 spaces left"),
+			},
+			preferences: inHouseholds(testPreferences(map[string]map[string]string{
+				"Art": {"Alice": PriorityHigh, "Yan": PriorityHigh, "Zoe": PriorityHigh},
+			}, "Alice", "Yan", "Zoe"),
+				Household{Name: "Smith", ChildNames: []string{"Alice"}},
+				Household{Name: "Jones", ChildNames: []string{"Yan", "Zoe"}}),
+			want:           map[string][]string{"Yan": {"Art " + week}, "Zoe": {"Art " + week}},
+			wantRejections: []string{"Alice: Art 2025-06-16 has 2 spots left, taken by 2 children of other families"},
+		},
+		{
+			name: "a full session names the child's friends",
+			sessions: []Session{
+				withAvailability(testSession("Art", week, "09:00", "12:00"), "2 spaces left"),
+			},
+			preferences: inHouseholds(withFriends(testPreferences(map[string]map[string]string{
+				"Art": {"Alice": PriorityHigh, "Yan": PriorityHigh, "Zoe": PriorityHigh},
+			}, "Alice", "Yan", "Zoe"), "Alice", "Zoe"),
+				Household{Name: "Smith", ChildNames: []string{"Alice"}},
+				Household{Name: "Jones", ChildNames: []string{"Yan", "Zoe"}}),
+			want:           map[string][]string{"Yan": {"Art " + week}, "Zoe": {"Art " + week}},
+			wantRejections: []string{"Alice: Art 2025-06-16 has 2 spots left, taken by Zoe, 1 child of another family"},
+		},
+		{
+			name: "each household keeps to its own budget",
+			sessions: []Session{
+				withPrice(testSession("Art", week, "09:00", "12:00"), "$150.00"),
+				withPrice(testSession("Swim", "2025-06-23", "09:00", "12:00"), "$150.00"),
+			},
+			preferences: inHouseholds(testPreferences(map[string]map[string]string{
+				"Art":  {"Alice": PriorityHigh, "Zoe": PriorityHigh},
+				"Swim": {"Alice": PriorityMedium, "Zoe": PriorityMedium},
+			}, "Alice", "Zoe"),
+				Household{Name: "Smith", ChildNames: []string{"Alice"}, Budget: 200},
+				Household{Name: "Jones", ChildNames: []string{"Zoe"}}),
+			want: map[string][]string{
+				"Alice": {"Art " + week},
+				"Zoe":   {"Art " + week, "Swim 2025-06-23"},
+			},
+			wantRejections: []string{"Alice: Swim 2025-06-23 costs $150.00, $50.00 of the budget left"},
+		},
+		{
+			name: "the friend bonus draws a child to a friend's session",
+			sessions: []Session{
+				testSession("Art", week, "09:00", "12:00"),
+				testSession("Swim", week, "09:00", "12:00"),
+			},
+			preferences: inHouseholds(withFriends(testPreferences(map[string]map[string]string{
+				"Art":  {"Alice": PriorityHigh},
+				"Swim": {"Alice": PriorityMedium, "Zoe": PriorityMust},
+			}, "Alice", "Zoe"), "Alice", "Zoe"),
+				Household{Name: "Smith", ChildNames: []string{"Alice"}},
+				Household{Name: "Jones", ChildNames: []string{"Zoe"}}),
+			options: func(options *Options) { options.FriendBonus = 4 },
+			want:    map[string][]string{"Alice": {"Swim " + week}, "Zoe": {"Swim " + week}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := DefaultOptions()
+			if test.options != nil {
+				test.options(&options)
+			}
+			result, planError := New(options).Plan(context.Background(), test.sessions, test.preferences)
+			if planError != nil {
+				t.Fatalf("Plan: %v", planError)
+			}
+			planned := plannedSessions(result)
+			for _, childName := range test.preferences.ChildNames() {
+				if !slices.Equal(planned[childName], test.want[childName]) {
+					t.Errorf("%s planned %q, want %q", childName, planned[childName], test.want[childName])
+				}
+			}
+			if !slices.Equal(result.Unmet, test.wantUnmet) {
+				t.Errorf("Unmet = %q, want %q", result.Unmet, test.wantUnmet)
+			}
+			if !slices.Equal(result.Rejections, test.wantRejections) {
+				t.Errorf("Rejections = %q, want %q", result.Rejections, test.wantRejections)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// planner/preferences.go
+package planner
+
+import (
+	"sort"
+	"time"
+)
+
+// Child is one child's age and personal constraints. With a Birthdate the age
+// is taken on each session's first day; otherwise Age is used. Zero limits
+// are not checked; MinFreeWeeks counts weeks without camp between the first
+// and last session date of all sessions.
+type Child struct {
+	Name                string
+	Age                 int
+	Birthdate           time.Time
+	Grade               string
+	MaxSessionsPerWeek  int
+	MaxMinutesPerDay    int
+	MaxMinutesPerWeek   int
+	MaxConsecutiveWeeks int
+	MinFreeWeeks        int
+	CategoryLimits      map[string]CategoryLimit
+	Blackouts           []Blackout
+	Coverage            []WeeklyWindow
+	Commitments         []Commitment
+	// Friends names children, of any household, this child likes to share sessions with.
+	Friends []string
+}
+
+// Preferences are the children and what each wants. Priorities maps an
+// activity title (a session's ActivityKey) to each child's priority value.
+// Budget caps the summed session prices of all children; with Households,
+// each household has its own budget instead.
+type Preferences struct {
+	Children   []Child
+	Priorities map[string]map[string]string
+	Scale      PriorityScale
+	Budget     float64
+	// Households groups the children of several families planned together;
+	// empty means every child is in one household.
+	Households []Household
+}
+
+// ChildNames lists the children's names, sorted.
+func (preferences Preferences) ChildNames() []string {
+	names := make([]string, 0, len(preferences.Children))
+	for _, child := range preferences.Children {
+		names = append(names, child.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ChildNamed finds a child by name.
+func (preferences Preferences) ChildNamed(childName string) (Child, bool) {
+	for _, child := range preferences.Children {
+		if child.Name == childName {
+			return child, true
+		}
+	}
+	return Child{}, false
+}
+
+// AgeOn returns the child's age on date, from the birthdate when one is known.
+func (child Child) AgeOn(date time.Time) int {
+	if child.Birthdate.IsZero() {
+		return child.Age
+	}
+	day := CalendarDate(date)
+	age := day.Year() - child.Birthdate.Year()
+	if day.Month() < child.Birthdate.Month() || (day.Month() == child.Birthdate.Month() && day.Day() < child.Birthdate.Day()) {
+		age--
+	}
+	return age
+}
+
+// Titles lists every activity title with priorities, sorted.
+func (preferences Preferences) Titles() []string {
+	titles := make([]string, 0, len(preferences.Priorities))
+	for title := range preferences.Priorities {
+		titles = append(titles, title)
+	}
+	sort.Strings(titles)
+	return titles
+}
+
+// PriorityOf returns the child's priority value for the session's activity.
+func (preferences Preferences) PriorityOf(childName string, session Session) string {
+	return preferences.Priorities[session.ActivityKey()][childName]
+}
+
+// ScoreOf returns the child's priority score for the session's activity.
+func (preferences Preferences) ScoreOf(childName string, session Session) int {
+	return preferences.Scale.Score(preferences.PriorityOf(childName, session))
+}
+
+// Wants reports whether the child gave the session a non-zero priority and is the right age for it.
+func (preferences Preferences) Wants(childName string, session Session) bool {
+	child, known := preferences.ChildNamed(childName)
+	return known && preferences.ScoreOf(childName, session) > 0 && session.AdmitsAge(child.AgeOn(session.StartDate()))
+}
@@ -0,0 +1,147 @@
+// planner/priority.go
+package planner
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// PriorityMust marks a hard requirement in the default scale.
+	PriorityMust           = "Must"
+	PriorityHigh           = "High"
+	PriorityMedium         = "Medium"
+	PriorityLow            = "Low"
+	PriorityNo             = "No"
+	minimumNumericPriority = 0
+	maximumNumericPriority = 10
+	// MustPriorityScore is the score of a Must priority.
+	MustPriorityScore = 100
+	// PriorityLevelMust, PriorityLevelHigh, PriorityLevelMedium and
+	// PriorityLevelLow are the display levels Level sorts scores into.
+	PriorityLevelMust   = "must"
+	PriorityLevelHigh   = "high"
+	PriorityLevelMedium = "medium"
+	PriorityLevelLow    = "low"
+	// priorityLevelBands splits the scale's top word score into equal bands,
+	// high first; the default scale's High, Medium and Low each get one.
+	priorityLevelBands = 3
+)
+
+// PriorityScale maps priority words to scores, case-insensitively.
+// Whole numbers between 0 and 10 are always accepted as their own score.
+// Must words are hard requirements.
+type PriorityScale struct {
+	scoreByWord map[string]int
+	mustWords   map[string]struct{}
+}
+
+// DefaultPriorityScale is High/Medium/Low/No = 3/2/1/0 plus Must.
+func DefaultPriorityScale() PriorityScale {
+	return NewPriorityScale(map[string]int{
+		PriorityHigh:   3,
+		PriorityMedium: 2,
+		PriorityLow:    1,
+		PriorityNo:     0,
+	}, []string{PriorityMust})
+}
+
+// NewPriorityScale builds a scale from word scores and hard-requirement words.
+func NewPriorityScale(scoreByWord map[string]int, mustWords []string) PriorityScale {
+	scale := PriorityScale{scoreByWord: map[string]int{}, mustWords: map[string]struct{}{}}
+	for word, score := range scoreByWord {
+		scale.scoreByWord[strings.ToLower(strings.TrimSpace(word))] = score
+	}
+	for _, word := range mustWords {
+		scale.mustWords[strings.ToLower(strings.TrimSpace(word))] = struct{}{}
+	}
+	return scale
+}
+
+// Lookup resolves a priority value; known is false for unrecognised text.
+func (scale PriorityScale) Lookup(value string) (score int, must bool, known bool) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	if normalized == emptyLiteral {
+		return 0, false, true
+	}
+	if _, isMust := scale.mustWords[normalized]; isMust {
+		return MustPriorityScore, true, true
+	}
+	if wordScore, isWord := scale.scoreByWord[normalized]; isWord {
+		return wordScore, false, true
+	}
+	if numeric, parseError := strconv.Atoi(normalized); parseError == nil && numeric >= minimumNumericPriority && numeric <= maximumNumericPriority {
+		return numeric, false, true
+	}
+	return 0, false, false
+}
+
+// Score returns the planning score of a priority value, zero when unknown.
+func (scale PriorityScale) Score(value string) int {
+	score, _, _ := scale.Lookup(value)
+	return score
+}
+
+// IsMust reports whether the value is a hard requirement.
+func (scale PriorityScale) IsMust(value string) bool {
+	_, must, _ := scale.Lookup(value)
+	return must
+}
+
+// Words lists the scale's words capitalised, highest score first, with the Must words leading.
+func (scale PriorityScale) Words() []string {
+	var mustWords, scoredWords []string
+	for word := range scale.mustWords {
+		mustWords = append(mustWords, word)
+	}
+	for word := range scale.scoreByWord {
+		scoredWords = append(scoredWords, word)
+	}
+	sort.Strings(mustWords)
+	sort.Slice(scoredWords, func(i, j int) bool {
+		if scale.scoreByWord[scoredWords[i]] != scale.scoreByWord[scoredWords[j]] {
+			return scale.scoreByWord[scoredWords[i]] > scale.scoreByWord[scoredWords[j]]
+		}
+		return scoredWords[i] < scoredWords[j]
+	})
+	words := append(mustWords, scoredWords...)
+	for wordIndex, word := range words {
+		if word != emptyLiteral {
+			words[wordIndex] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return words
+}
+
+// Level sorts a score into a display level: Must scores are PriorityLevelMust
+// and the rest fall into thirds of the scale's highest word score, so on the
+// default scale 3, 2 and 1 are high, medium and low. Numbers above the top
+// word score count as high.
+func (scale PriorityScale) Level(score int) string {
+	if score >= MustPriorityScore {
+		return PriorityLevelMust
+	}
+	topScore := 1
+	for _, wordScore := range scale.scoreByWord {
+		topScore = max(topScore, wordScore)
+	}
+	switch {
+	case score*priorityLevelBands > topScore*(priorityLevelBands-1):
+		return PriorityLevelHigh
+	case score*priorityLevelBands > topScore:
+		return PriorityLevelMedium
+	default:
+		return PriorityLevelLow
+	}
+}
+
+// WithWord returns a copy of the scale that also scores word.
+func (scale PriorityScale) WithWord(word string, score int) PriorityScale {
+	extended := PriorityScale{scoreByWord: map[string]int{}, mustWords: scale.mustWords}
+	for existingWord, existingScore := range scale.scoreByWord {
+		extended.scoreByWord[existingWord] = existingScore
+	}
+	extended.scoreByWord[strings.ToLower(strings.TrimSpace(word))] = score
+	return extended
+}
@@ -0,0 +1,58 @@
+// planner/priority_test.go
+package planner
+
+import "testing"
+
+func TestPriorityScaleLookup(t *testing.T) {
+	scale := DefaultPriorityScale()
+	tests := []struct {
+		value     string
+		wantScore int
+		wantMust  bool
+		wantKnown bool
+	}{
+		{value: "High", wantScore: 3, wantKnown: true},
+		{value: " medium ", wantScore: 2, wantKnown: true},
+		{value: "LOW", wantScore: 1, wantKnown: true},
+		{value: "must", wantScore: MustPriorityScore, wantMust: true, wantKnown: true},
+		{value: "7", wantScore: 7, wantKnown: true},
+		{value: emptyLiteral, wantKnown: true},
+		{value: "11"},
+		{value: "Hihg"},
+	}
+	for _, test := range tests {
+		score, must, known := scale.Lookup(test.value)
+		if score != test.wantScore || must != test.wantMust || known != test.wantKnown {
+			t.Errorf("Lookup(%q) = %d, %t, %t; want %d, %t, %t", test.value, score, must, known, test.wantScore, test.wantMust, test.wantKnown)
+		}
+	}
+	if extended := scale.WithWord("Maybe", 1); extended.Score("maybe") != 1 {
+		t.Error("WithWord did not add the word")
+	}
+	if _, _, known := scale.Lookup("maybe"); known {
+		t.Error("WithWord changed the original scale")
+	}
+}
+
+func TestPriorityScaleLevel(t *testing.T) {
+	custom := NewPriorityScale(map[string]int{"Love": 9, "Like": 5, "Meh": 2}, nil)
+	tests := []struct {
+		scale PriorityScale
+		score int
+		want  string
+	}{
+		{scale: DefaultPriorityScale(), score: 3, want: PriorityLevelHigh},
+		{scale: DefaultPriorityScale(), score: 2, want: PriorityLevelMedium},
+		{scale: DefaultPriorityScale(), score: 1, want: PriorityLevelLow},
+		{scale: DefaultPriorityScale(), score: 10, want: PriorityLevelHigh},
+		{scale: DefaultPriorityScale(), score: MustPriorityScore, want: PriorityLevelMust},
+		{scale: custom, score: 9, want: PriorityLevelHigh},
+		{scale: custom, score: 5, want: PriorityLevelMedium},
+		{scale: custom, score: 2, want: PriorityLevelLow},
+	}
+	for _, test := range tests {
+		if level := test.scale.Level(test.score); level != test.want {
+			t.Errorf("Level(%d) on %v = %q, want %q", test.score, test.scale.scoreByWord, level, test.want)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// planner/registration.go
+package planner
+
+import (
+	"sort"
+	"time"
+)
+
+// RegistrationItem is one session to register, for every child the plan puts in it.
+type RegistrationItem struct {
+	Session    Session
+	ChildNames []string
+	// Score is the highest priority score among ChildNames.
+	Score int
+}
+
+// RegistrationGroup holds the items whose registration opens at the same minute.
+// Opens is zero for sessions without a known opening moment.
+type RegistrationGroup struct {
+	Opens time.Time
+	Items []RegistrationItem
+}
+
+// RegistrationChecklist groups the planned sessions by registration opening
+// moment, earliest first, with unknown moments last. Within a group the order
+// is the order to click through: highest priority first, then the session
+// with the fewest spaces left, then the earliest start.
+func (result *Result) RegistrationChecklist(preferences Preferences) []RegistrationGroup {
+	itemsByKey := map[string]*RegistrationItem{}
+	var keys []string
+	for _, childName := range result.ChildNames {
+		for _, session := range result.Plans[childName].Sessions {
+			key := session.Key()
+			item, seen := itemsByKey[key]
+			if !seen {
+				item = &RegistrationItem{Session: session}
+				itemsByKey[key] = item
+				keys = append(keys, key)
+			}
+			item.ChildNames = append(item.ChildNames, childName)
+			item.Score = max(item.Score, preferences.ScoreOf(childName, session))
+		}
+	}
+
+	groupsByOpening := map[time.Time]*RegistrationGroup{}
+	var groups []*RegistrationGroup
+	for _, key := range keys {
+		item := itemsByKey[key]
+		opens, _ := item.Session.RegistrationOpensAt()
+		group, seen := groupsByOpening[opens]
+		if !seen {
+			group = &RegistrationGroup{Opens: opens}
+			groupsByOpening[opens] = group
+			groups = append(groups, group)
+		}
+		group.Items = append(group.Items, *item)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Opens.IsZero() != groups[j].Opens.IsZero() {
+			return groups[j].Opens.IsZero()
+		}
+		return groups[i].Opens.Before(groups[j].Opens)
+	})
+	checklist := make([]RegistrationGroup, 0, len(groups))
+	for _, group := range groups {
+		sort.SliceStable(group.Items, func(i, j int) bool {
+			return registersBefore(group.Items[i], group.Items[j])
+		})
+		checklist = append(checklist, *group)
+	}
+	return checklist
+}
+
+// registersBefore orders two items opening at the same minute.
+func registersBefore(first, second RegistrationItem) bool {
+	if first.Score != second.Score {
+		return first.Score > second.Score
+	}
+	firstSpaces, firstKnown := first.Session.SpotsLeft()
+	secondSpaces, secondKnown := second.Session.SpotsLeft()
+	if firstKnown != secondKnown {
+		return firstKnown
+	}
+	if firstKnown && firstSpaces != secondSpaces {
+		return firstSpaces < secondSpaces
+	}
+	return first.Session.StartDate().Before(second.Session.StartDate())
+}
@@ -0,0 +1,76 @@
+// planner/registration_test.go
+package planner
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// opening returns the session with its registration moment and availability text.
+func opening(session Session, opens, availability string) Session {
+	session.RegistrationOpens = opens
+	session.AvailabilityText = availability
+	return session
+}
+
+func TestRegistrationChecklist(t *testing.T) {
+	const week = "2025-06-16"
+	art := opening(testSession("Art", week, "09:00", "12:00"), "2025-03-01T09:00", "10 spaces left")
+	swim := opening(testSession("Swim", week, "13:00", "15:00"), "2025-03-01T09:00", "2 spaces left")
+	chess := opening(testSession("Chess", "2025-06-23", "09:00", "12:00"), "2025-03-01T09:00", "Open")
+	drama := opening(testSession("Drama", "2025-06-30", "09:00", "12:00"), "2025-02-15T08:00", emptyLiteral)
+	music := testSession("Music", "2025-07-07", "09:00", "12:00")
+
+	preferences := testPreferences(map[string]map[string]string{
+		"Art":   {"Alice": PriorityMedium, "Bob": PriorityMedium},
+		"Swim":  {"Alice": PriorityMedium},
+		"Chess": {"Bob": PriorityMust},
+		"Drama": {"Alice": PriorityLow},
+		"Music": {"Bob": PriorityLow},
+	}, "Alice", "Bob")
+	result := &Result{
+		ChildNames: []string{"Alice", "Bob"},
+		Plans: map[string]*Plan{
+			"Alice": {Sessions: []Session{art, swim, drama}},
+			"Bob":   {Sessions: []Session{music, chess, art}},
+		},
+	}
+
+	// The February opening comes first and the unknown one last; at
+	// 2025-03-01 09:00 the must goes first, then the scarcer of the two
+	// medium sessions. Art appears once, for both children.
+	var got []string
+	for _, group := range result.RegistrationChecklist(preferences) {
+		var titles []string
+		for _, item := range group.Items {
+			titles = append(titles, item.Session.ActivityName+" ("+strings.Join(item.ChildNames, "+")+")")
+		}
+		opens := "unknown"
+		if !group.Opens.IsZero() {
+			opens = group.Opens.Format(RegistrationLayout)
+		}
+		got = append(got, opens+": "+strings.Join(titles, ", "))
+	}
+	want := []string{
+		"2025-02-15T08:00: Drama (Alice)",
+		"2025-03-01T09:00: Chess (Bob), Swim (Alice), Art (Alice+Bob)",
+		"unknown: Music (Bob)",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("checklist =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestRegistersBeforeFallsBackToStartDate(t *testing.T) {
+	early := RegistrationItem{Session: testSession("Art", "2025-06-16", "09:00", "12:00"), Score: 2}
+	late := RegistrationItem{Session: testSession("Art", "2025-06-23", "09:00", "12:00"), Score: 2}
+	if !registersBefore(early, late) || registersBefore(late, early) {
+		t.Error("equal priority and unknown spaces should order by start date")
+	}
+	known := late
+	known.Session.AvailabilityText = "5 spaces left"
+	if !registersBefore(known, early) {
+		t.Error("a session with known spaces left should come before one without")
+	}
+}
@@ -0,0 +1,175 @@
+// planner/rules.go
+package planner
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rules relax and extend the one-session-per-activity rule. Activities are
+// named by ActivityKey: the want title a session matched, else its own title.
+type Rules struct {
+	// MaxRepeats lets a child take up to that many sessions of an activity; unlisted activities allow one.
+	MaxRepeats map[string]int
+	// Families share one session quota across several activities.
+	Families []ActivityFamily
+	// Prerequisites lists, per activity, the activities a session of which must end before it starts.
+	Prerequisites map[string][]string
+	// Bundles are groups of activities a child takes all of or none of.
+	Bundles [][]string
+}
+
+// ActivityFamily caps the sessions of its activities together; MaxSessions 0 allows one.
+type ActivityFamily struct {
+	Name        string
+	Activities  []string
+	MaxSessions int
+}
+
+func (rules Rules) maxRepeats(activity string) int {
+	if repeats := rules.MaxRepeats[activity]; repeats > 0 {
+		return repeats
+	}
+	return 1
+}
+
+func (rules Rules) familiesOf(activity string) []ActivityFamily {
+	var families []ActivityFamily
+	for _, family := range rules.Families {
+		if containsString(family.Activities, activity) {
+			families = append(families, family)
+		}
+	}
+	return families
+}
+
+func (rules Rules) bundlesOf(activity string) [][]string {
+	var bundles [][]string
+	for _, bundle := range rules.Bundles {
+		if containsString(bundle, activity) {
+			bundles = append(bundles, bundle)
+		}
+	}
+	return bundles
+}
+
+// allowsActivity checks the candidate against the activity's repeat limit,
+// its families' quotas and every prerequisite in the plan with it added.
+func (plan *Plan) allowsActivity(candidate Session) bool {
+	activity := candidate.ActivityKey()
+	if plan.activitySessions(activity) >= plan.rules.maxRepeats(activity) {
+		return false
+	}
+	for _, family := range plan.rules.familiesOf(activity) {
+		taken := 0
+		for _, member := range family.Activities {
+			taken += plan.activitySessions(member)
+		}
+		if taken >= max(family.MaxSessions, 1) {
+			return false
+		}
+	}
+	return plan.prerequisitesHold(candidate)
+}
+
+// activitySessions counts the plan's sessions of an activity.
+func (plan *Plan) activitySessions(activity string) int {
+	count := 0
+	for _, session := range plan.Sessions {
+		if session.ActivityKey() == activity {
+			count++
+		}
+	}
+	return count
+}
+
+// prerequisitesHold reports whether, with the candidate added, every session
+// has a session of each of its prerequisites ending before it starts.
+func (plan *Plan) prerequisitesHold(candidate Session) bool {
+	if len(plan.rules.Prerequisites) == 0 {
+		return true
+	}
+	sessions := append(append([]Session(nil), plan.Sessions...), candidate)
+	for _, session := range sessions {
+		for _, prerequisite := range plan.rules.Prerequisites[session.ActivityKey()] {
+			met := false
+			for _, earlier := range sessions {
+				if earlier.ActivityKey() == prerequisite && CalendarDate(earlier.EndDate()).Before(CalendarDate(session.StartDate())) {
+					met = true
+					break
+				}
+			}
+			if !met {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// bundleGain is a Must-sized score for a candidate completing a bundle the
+// child has already started.
+func (plan *Plan) bundleGain(candidate Session) int {
+	activity := candidate.ActivityKey()
+	if plan.activitySessions(activity) > 0 {
+		return 0
+	}
+	for _, bundle := range plan.rules.bundlesOf(activity) {
+		for _, member := range bundle {
+			if member != activity && plan.activitySessions(member) > 0 {
+				return MustPriorityScore
+			}
+		}
+	}
+	return 0
+}
+
+// incompleteBundles lists the bundles the plan holds some but not all activities of.
+func (plan *Plan) incompleteBundles() [][]string {
+	var incomplete [][]string
+	for _, bundle := range plan.rules.Bundles {
+		taken := 0
+		for _, member := range bundle {
+			if plan.activitySessions(member) > 0 {
+				taken++
+			}
+		}
+		if taken > 0 && taken < len(bundle) {
+			incomplete = append(incomplete, bundle)
+		}
+	}
+	return incomplete
+}
+
+// withoutActivities copies the preferences with the child's priorities for
+// the activities removed, so the planner no longer considers them.
+func (preferences Preferences) withoutActivities(childName string, activities []string) Preferences {
+	priorities := map[string]map[string]string{}
+	for activity, priorityByChild := range preferences.Priorities {
+		priorities[activity] = priorityByChild
+		if containsString(activities, activity) {
+			trimmed := map[string]string{}
+			for otherName, priorityValue := range priorityByChild {
+				if otherName != childName {
+					trimmed[otherName] = priorityValue
+				}
+			}
+			priorities[activity] = trimmed
+		}
+	}
+	preferences.Priorities = priorities
+	return preferences
+}
+
+func bundleRejection(childName string, bundle []string) string {
+	return fmt.Sprintf("%s: %s dropped, cannot take the whole bundle", childName, strings.Join(bundle, " + "))
+}
+
+func containsString(values []string, wanted string) bool {
+	for _, value := range values {
+		if value == wanted {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,263 @@
+// planner/session.go
+
+// Package planner picks summer-camp sessions for several children at once.
+// It holds the planning model only; reading CSV, JSON and calendar files and
+// printing results is left to the command-line tools.
+package planner
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	emptyLiteral                           = ""
+	availabilityAvailableLiteral           = "available"
+	availabilityStartingSoonLiteral        = "starting soon"
+	availabilitySpaceLeftIdentifierLiteral = "space"
+	availabilityLeftIdentifierLiteral      = "left"
+	dateLayoutISOLiteral                   = "2006-01-02"
+	// RegistrationLayout formats Session.RegistrationOpens.
+	RegistrationLayout = "2006-01-02T15:04"
+)
+
+var spacesLeftPattern = regexp.MustCompile(`(?i)(\d+)\s+spaces?\s+left`)
+
+// Session is one scraped camp session.
+type Session struct {
+	ActivityName         string   `json:"title"`
+	StartDateUnixSeconds int64    `json:"startDateUnix"`
+	EndDateUnixSeconds   int64    `json:"endDateUnix"`
+	DaysOfWeek           []string `json:"days"`
+	StartTimeMilitary    string   `json:"startTime"`
+	EndTimeMilitary      string   `json:"endTime"`
+	MinimumAgeInclusive  *int     `json:"minAge"`
+	MaximumAgeExclusive  *int     `json:"maxAge"`
+	AvailabilityText     string   `json:"availability"`
+	PageURL              string   `json:"pageUrl"`
+	Location             string   `json:"location"`
+	Price                string   `json:"price"`
+	ActivityNumber       string   `json:"activityNumber"`
+	DetailURL            string   `json:"detailUrl"`
+	// RegistrationOpens is the local wall-clock moment registration opens,
+	// formatted as RegistrationLayout; empty when the scraper did not find it.
+	RegistrationOpens string `json:"registrationOpens"`
+	// MatchedTitle is the preference title the session was matched to when it
+	// differs from the scraped title; see ActivityKey.
+	MatchedTitle string `json:"-"`
+	// Categories are the session's tags from a CategoryTable.
+	Categories []string `json:"-"`
+}
+
+// ParseSessions decodes scraper JSON, skipping entries without a title.
+func ParseSessions(jsonBytes []byte) ([]Session, error) {
+	var rawData []map[string]interface{}
+	if decodeError := json.Unmarshal(jsonBytes, &rawData); decodeError != nil {
+		return nil, decodeError
+	}
+
+	getStringField := func(m map[string]interface{}, key string) string {
+		if v, ok := m[key]; ok {
+			if s, ok := v.(string); ok {
+				return s
+			}
+		}
+		return emptyLiteral
+	}
+
+	getInt64Field := func(m map[string]interface{}, key string) int64 {
+		if v, ok := m[key]; ok {
+			switch tv := v.(type) {
+			case float64:
+				return int64(tv)
+			case json.Number:
+				i, _ := tv.Int64()
+				return i
+			}
+		}
+		return 0
+	}
+
+	var sessions []Session
+	for _, rawEntry := range rawData {
+		title := getStringField(rawEntry, "title")
+		if title == emptyLiteral {
+			continue
+		}
+
+		var daysSlice []string
+		if rawDays, ok := rawEntry["days"].([]interface{}); ok {
+			for _, dayInterface := range rawDays {
+				if dayString, ok := dayInterface.(string); ok {
+					daysSlice = append(daysSlice, dayString)
+				}
+			}
+		}
+
+		var minimumAgePointer, maximumAgePointer *int
+		if value, ok := rawEntry["minAge"].(float64); ok {
+			temp := int(value)
+			minimumAgePointer = &temp
+		}
+		if value, ok := rawEntry["maxAge"].(float64); ok {
+			temp := int(value)
+			maximumAgePointer = &temp
+		}
+
+		sessions = append(sessions, Session{
+			ActivityName:         title,
+			StartDateUnixSeconds: getInt64Field(rawEntry, "startDateUnix"),
+			EndDateUnixSeconds:   getInt64Field(rawEntry, "endDateUnix"),
+			DaysOfWeek:           daysSlice,
+			StartTimeMilitary:    getStringField(rawEntry, "startTime"),
+			EndTimeMilitary:      getStringField(rawEntry, "endTime"),
+			MinimumAgeInclusive:  minimumAgePointer,
+			MaximumAgeExclusive:  maximumAgePointer,
+			AvailabilityText:     getStringField(rawEntry, "availability"),
+			PageURL:              getStringField(rawEntry, "pageUrl"),
+			Location:             getStringField(rawEntry, "location"),
+			Price:                getStringField(rawEntry, "price"),
+			ActivityNumber:       getStringField(rawEntry, "activityNumber"),
+			DetailURL:            getStringField(rawEntry, "detailUrl"),
+			RegistrationOpens:    getStringField(rawEntry, "registrationOpens"),
+		})
+	}
+	return sessions, nil
+}
+
+// StartDate is the session's first day.
+func (session Session) StartDate() time.Time {
+	return time.Unix(session.StartDateUnixSeconds, 0)
+}
+
+// EndDate is the session's last day.
+func (session Session) EndDate() time.Time {
+	return time.Unix(session.EndDateUnixSeconds, 0)
+}
+
+// StartClockMinutes is the daily start time in minutes after midnight.
+func (session Session) StartClockMinutes() int {
+	return MilitaryTimeToMinutes(session.StartTimeMilitary)
+}
+
+// EndClockMinutes is the daily end time in minutes after midnight.
+func (session Session) EndClockMinutes() int {
+	return MilitaryTimeToMinutes(session.EndTimeMilitary)
+}
+
+// Key identifies one concrete session; titles repeat across weeks.
+func (session Session) Key() string {
+	return fmt.Sprintf("%s|%d|%s", session.ActivityName, session.StartDateUnixSeconds, session.StartTimeMilitary)
+}
+
+// ActivityKey names the activity a session belongs to: its matched preference
+// title when set, otherwise its scraped title. A child takes one session per activity.
+func (session Session) ActivityKey() string {
+	if session.MatchedTitle != emptyLiteral {
+		return session.MatchedTitle
+	}
+	return session.ActivityName
+}
+
+// MeetingDates lists every calendar date on which the session meets.
+func (session Session) MeetingDates() []time.Time {
+	meetsOn := map[string]struct{}{}
+	for _, day := range session.DaysOfWeek {
+		meetsOn[day] = struct{}{}
+	}
+	var meetingDates []time.Time
+	lastDate := CalendarDate(session.EndDate())
+	for date := CalendarDate(session.StartDate()); !date.After(lastDate); date = date.AddDate(0, 0, 1) {
+		if _, meets := meetsOn[WeekdayAbbreviation(date)]; meets {
+			meetingDates = append(meetingDates, date)
+		}
+	}
+	return meetingDates
+}
+
+// Link is the session's own page when the scraper found one, else the search page.
+func (session Session) Link() string {
+	if session.DetailURL != emptyLiteral {
+		return session.DetailURL
+	}
+	return session.PageURL
+}
+
+// RegistrationOpensAt parses RegistrationOpens; ok is false when it is missing or malformed.
+func (session Session) RegistrationOpensAt() (opens time.Time, ok bool) {
+	if session.RegistrationOpens == emptyLiteral {
+		return time.Time{}, false
+	}
+	opens, parseError := time.Parse(RegistrationLayout, session.RegistrationOpens)
+	return opens, parseError == nil
+}
+
+// AdmitsAge reports whether a child of age fits the session's age bounds.
+func (session Session) AdmitsAge(age int) bool {
+	minimum := 0
+	maximum := 1<<31 - 1
+	if session.MinimumAgeInclusive != nil {
+		minimum = *session.MinimumAgeInclusive
+	}
+	if session.MaximumAgeExclusive != nil {
+		maximum = *session.MaximumAgeExclusive
+	}
+	return age >= minimum && age < maximum
+}
+
+// weeks returns the Mondays of every week the session meets in.
+func (session Session) weeks() map[time.Time]struct{} {
+	weeks := map[time.Time]struct{}{}
+	for _, date := range session.MeetingDates() {
+		weeks[WeekStartOf(date)] = struct{}{}
+	}
+	return weeks
+}
+
+func sessionsOverlap(sessionA, sessionB Session, bufferMinutes int) bool {
+	if sessionA.EndDate().Before(sessionB.StartDate()) || sessionB.EndDate().Before(sessionA.StartDate()) {
+		return false
+	}
+
+	shareDay := false
+	for _, dayA := range sessionA.DaysOfWeek {
+		for _, dayB := range sessionB.DaysOfWeek {
+			if dayA == dayB {
+				shareDay = true
+				break
+			}
+		}
+		if shareDay {
+			break
+		}
+	}
+	if !shareDay {
+		return false
+	}
+
+	if sessionA.EndClockMinutes()+bufferMinutes <= sessionB.StartClockMinutes() ||
+		sessionB.EndClockMinutes()+bufferMinutes <= sessionA.StartClockMinutes() {
+		return false
+	}
+	return true
+}
+
+// MilitaryTimeToMinutes converts "HH:MM" to minutes after midnight, 0 when malformed.
+func MilitaryTimeToMinutes(military string) int {
+	parts := strings.Split(military, ":")
+	if len(parts) != 2 {
+		return 0
+	}
+	hours, _ := strconv.Atoi(parts[0])
+	minutes, _ := strconv.Atoi(parts[1])
+	return hours*60 + minutes
+}
+
+// MinutesToMilitaryTime formats minutes after midnight as "HH:MM".
+func MinutesToMilitaryTime(clockMinutes int) string {
+	return fmt.Sprintf("%02d:%02d", clockMinutes/60, clockMinutes%60)
+}